@@ -0,0 +1,202 @@
+package bloom
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"generalization_tool/mapx"
+	"math"
+	"math/bits"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*BloomFilter[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*BloomFilter[int])(nil)
+)
+
+var (
+	errBloomFilterInvalidCount = errors.New("BloomFilter：预期元素数量必须大于0")
+	errBloomFilterInvalidRate  = errors.New("BloomFilter：误判率必须在0和1之间")
+	errBloomFilterHasherIsNull = errors.New("BloomFilter：hasher不能为nil")
+	errBloomFilterIncompatible = errors.New("BloomFilter：位数组大小或者哈希函数个数不一致")
+	errBloomFilterInvalidData  = errors.New("BloomFilter：序列化的数据不合法")
+)
+
+const (
+	// bloomFilterVersion 序列化格式的版本号，格式修改时需要增加
+	bloomFilterVersion = 1
+	// bloomFilterHeaderSize 序列化头部的长度：版本号 1 字节，哈希函数个数 4 字节，位数组大小 8 字节
+	bloomFilterHeaderSize = 1 + 4 + 8
+)
+
+// BloomFilter 布隆过滤器，用很小的空间判断元素是否可能存在，不是并发安全的
+// Exist 返回 false 时元素一定不存在；返回 true 时元素可能存在，误判的概率由创建时的参数决定。
+// 元素只能添加不能删除。
+// 每个元素只计算一次哈希值，再通过双重哈希 g(i) = h1 + i*h2 得到 k 个位置
+type BloomFilter[T any] struct {
+	bits []uint64
+	// m 位数组的大小
+	m uint64
+	// k 哈希函数的个数
+	k      uint32
+	hasher func(T) uint64
+}
+
+// NewBloomFilter 创建一个布隆过滤器，使用元素的 Code() 作为哈希值
+// n 是预期的元素数量，p 是预期的误判率，元素数量超过 n 之后误判率会升高
+func NewBloomFilter[T mapx.Hashable](n uint64, p float64) (*BloomFilter[T], error) {
+	return NewBloomFilterWithHasher[T](n, p, func(key T) uint64 {
+		return key.Code()
+	})
+}
+
+// NewBloomFilterWithHasher 创建一个使用 hasher 计算哈希值的布隆过滤器，hasher不能为nil
+// n 是预期的元素数量，p 是预期的误判率，元素数量超过 n 之后误判率会升高
+func NewBloomFilterWithHasher[T any](n uint64, p float64, hasher func(T) uint64) (*BloomFilter[T], error) {
+	if n == 0 {
+		return nil, errBloomFilterInvalidCount
+	}
+	if !(p > 0 && p < 1) {
+		return nil, errBloomFilterInvalidRate
+	}
+	if hasher == nil {
+		return nil, errBloomFilterHasherIsNull
+	}
+	// 最优的位数组大小 m = -n*ln(p)/(ln2)^2，最优的哈希函数个数 k = m/n*ln2
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	// 向上取整到 64 的倍数，多出来的位不会浪费
+	m = (m + 63) / 64 * 64
+	k := uint32(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+	return &BloomFilter[T]{
+		bits:   make([]uint64, m/64),
+		m:      m,
+		k:      k,
+		hasher: hasher,
+	}, nil
+}
+
+// Add 添加元素
+func (b *BloomFilter[T]) Add(key T) {
+	h1, h2 := b.hash(key)
+	for i := uint64(0); i < uint64(b.k); i++ {
+		idx := (h1 + i*h2) % b.m
+		b.bits[idx/64] |= 1 << (idx % 64)
+	}
+}
+
+// Exist 判断元素是否可能存在，返回 false 时元素一定不存在
+func (b *BloomFilter[T]) Exist(key T) bool {
+	h1, h2 := b.hash(key)
+	for i := uint64(0); i < uint64(b.k); i++ {
+		idx := (h1 + i*h2) % b.m
+		if b.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear 删除所有元素
+func (b *BloomFilter[T]) Clear() {
+	for i := range b.bits {
+		b.bits[i] = 0
+	}
+}
+
+// Cap 返回位数组的大小
+func (b *BloomFilter[T]) Cap() uint64 {
+	return b.m
+}
+
+// HashCount 返回每个元素使用的哈希函数的个数
+func (b *BloomFilter[T]) HashCount() uint32 {
+	return b.k
+}
+
+// EstimatedCount 根据被置为 1 的位的数量估算已经添加的元素数量
+func (b *BloomFilter[T]) EstimatedCount() uint64 {
+	ones := 0
+	for _, word := range b.bits {
+		ones += bits.OnesCount64(word)
+	}
+	if uint64(ones) == b.m {
+		return math.MaxUint64
+	}
+	// n ≈ -m/k * ln(1 - X/m)，X 是被置为 1 的位的数量
+	m := float64(b.m)
+	return uint64(math.Round(-m / float64(b.k) * math.Log(1-float64(ones)/m)))
+}
+
+// Union 返回包含两个过滤器中所有元素的新过滤器，不会修改 b 和 other
+// 两个过滤器必须使用相同的参数和相同的哈希函数创建，参数不一致时返回错误
+func (b *BloomFilter[T]) Union(other *BloomFilter[T]) (*BloomFilter[T], error) {
+	return b.merge(other, func(x, y uint64) uint64 {
+		return x | y
+	})
+}
+
+// Intersect 返回两个过滤器的交集，不会修改 b 和 other
+// 结果的误判率不低于分别添加交集中的元素得到的过滤器。
+// 两个过滤器必须使用相同的参数和相同的哈希函数创建，参数不一致时返回错误
+func (b *BloomFilter[T]) Intersect(other *BloomFilter[T]) (*BloomFilter[T], error) {
+	return b.merge(other, func(x, y uint64) uint64 {
+		return x & y
+	})
+}
+
+// MarshalBinary 序列化为字节数组，哈希函数不会被序列化
+func (b *BloomFilter[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, bloomFilterHeaderSize+len(b.bits)*8)
+	data[0] = bloomFilterVersion
+	binary.BigEndian.PutUint32(data[1:5], b.k)
+	binary.BigEndian.PutUint64(data[5:13], b.m)
+	for i, word := range b.bits {
+		binary.BigEndian.PutUint64(data[bloomFilterHeaderSize+i*8:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 得到的字节数组中恢复过滤器的内容
+// 会覆盖 b 原有的位数组和参数，但是保留 b 的哈希函数，所以 b 必须和序列化之前的过滤器使用相同的哈希函数
+func (b *BloomFilter[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomFilterHeaderSize || data[0] != bloomFilterVersion {
+		return errBloomFilterInvalidData
+	}
+	k := binary.BigEndian.Uint32(data[1:5])
+	m := binary.BigEndian.Uint64(data[5:13])
+	if k == 0 || m == 0 || m%64 != 0 || uint64(len(data)-bloomFilterHeaderSize) != m/8 {
+		return errBloomFilterInvalidData
+	}
+	words := make([]uint64, m/64)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(data[bloomFilterHeaderSize+i*8:])
+	}
+	b.bits, b.m, b.k = words, m, k
+	return nil
+}
+
+func (b *BloomFilter[T]) merge(other *BloomFilter[T], fn func(x, y uint64) uint64) (*BloomFilter[T], error) {
+	if b.m != other.m || b.k != other.k {
+		return nil, errBloomFilterIncompatible
+	}
+	words := make([]uint64, len(b.bits))
+	for i := range words {
+		words[i] = fn(b.bits[i], other.bits[i])
+	}
+	return &BloomFilter[T]{
+		bits:   words,
+		m:      b.m,
+		k:      b.k,
+		hasher: b.hasher,
+	}, nil
+}
+
+// hash 对元素的哈希值再做一次混淆，避免 Code() 分布不均匀，h2 取奇数避免多个位置重合
+func (b *BloomFilter[T]) hash(key T) (uint64, uint64) {
+	h1 := mapx.IntegerHash(b.hasher(key))
+	h2 := mapx.IntegerHash(h1) | 1
+	return h1, h2
+}
@@ -0,0 +1,167 @@
+package bloom
+
+import (
+	"generalization_tool/mapx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewBloomFilter(t *testing.T) {
+	testCases := []struct {
+		name      string
+		n         uint64
+		p         float64
+		hasher    func(int) uint64
+		wantErr   error
+		wantCap   uint64
+		wantCount uint32
+	}{
+		{name: "zero count", n: 0, p: 0.01, hasher: mapx.IntegerHash[int], wantErr: errBloomFilterInvalidCount},
+		{name: "zero rate", n: 10, p: 0, hasher: mapx.IntegerHash[int], wantErr: errBloomFilterInvalidRate},
+		{name: "rate one", n: 10, p: 1, hasher: mapx.IntegerHash[int], wantErr: errBloomFilterInvalidRate},
+		{name: "nil hasher", n: 10, p: 0.01, wantErr: errBloomFilterHasherIsNull},
+		// m = ceil(1000*ln(100)/ln2^2) = 9586，向上取整到 9600，k = round(9.6*ln2) = 7
+		{name: "one percent", n: 1000, p: 0.01, hasher: mapx.IntegerHash[int], wantCap: 9600, wantCount: 7},
+		{name: "high rate", n: 1, p: 0.9, hasher: mapx.IntegerHash[int], wantCap: 64, wantCount: 44},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBloomFilterWithHasher[int](tc.n, tc.p, tc.hasher)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantCap, b.Cap())
+			assert.Equal(t, tc.wantCount, b.HashCount())
+		})
+	}
+}
+
+func TestBloomFilter_FalsePositiveRate(t *testing.T) {
+	const n, p = 10000, 0.01
+	b, err := NewBloomFilterWithHasher[int](n, p, mapx.IntegerHash[int])
+	assert.NoError(t, err)
+	for i := 0; i < n; i++ {
+		b.Add(i)
+	}
+	// 添加过的元素一定存在
+	for i := 0; i < n; i++ {
+		assert.True(t, b.Exist(i))
+	}
+	falsePositives := 0
+	for i := n; i < n*11; i++ {
+		if b.Exist(i) {
+			falsePositives++
+		}
+	}
+	rate := float64(falsePositives) / (n * 10)
+	assert.Less(t, rate, p*1.5)
+
+	count := b.EstimatedCount()
+	assert.InDelta(t, n, count, n*0.05)
+
+	b.Clear()
+	assert.False(t, b.Exist(0))
+	assert.Equal(t, uint64(0), b.EstimatedCount())
+}
+
+func TestBloomFilter_Hashable(t *testing.T) {
+	b, err := NewBloomFilter[testData](100, 0.01)
+	assert.NoError(t, err)
+	// testData 的 Code() 只有 10 种取值，Code() 相同的元素无法区分
+	b.Add(testData{id: 1})
+	assert.True(t, b.Exist(testData{id: 1}))
+	assert.True(t, b.Exist(testData{id: 11}))
+	assert.False(t, b.Exist(testData{id: 2}))
+}
+
+func TestBloomFilter_UnionIntersect(t *testing.T) {
+	newFilter := func(keys ...int) *BloomFilter[int] {
+		b, err := NewBloomFilterWithHasher[int](100, 0.001, mapx.IntegerHash[int])
+		assert.NoError(t, err)
+		for _, k := range keys {
+			b.Add(k)
+		}
+		return b
+	}
+	a := newFilter(1, 2, 3)
+	b := newFilter(3, 4, 5)
+
+	union, err := a.Union(b)
+	assert.NoError(t, err)
+	for _, k := range []int{1, 2, 3, 4, 5} {
+		assert.True(t, union.Exist(k))
+	}
+	assert.False(t, union.Exist(6))
+
+	intersect, err := a.Intersect(b)
+	assert.NoError(t, err)
+	assert.True(t, intersect.Exist(3))
+	assert.False(t, intersect.Exist(1))
+	assert.False(t, intersect.Exist(5))
+
+	// 原来的过滤器不受影响
+	assert.False(t, a.Exist(4))
+	assert.False(t, b.Exist(1))
+
+	other, err := NewBloomFilterWithHasher[int](1000, 0.001, mapx.IntegerHash[int])
+	assert.NoError(t, err)
+	_, err = a.Union(other)
+	assert.Equal(t, errBloomFilterIncompatible, err)
+	_, err = a.Intersect(other)
+	assert.Equal(t, errBloomFilterIncompatible, err)
+}
+
+func TestBloomFilter_Binary(t *testing.T) {
+	src, err := NewBloomFilterWithHasher[int](100, 0.01, mapx.IntegerHash[int])
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		src.Add(i * 7)
+	}
+	data, err := src.MarshalBinary()
+	assert.NoError(t, err)
+
+	// 参数不同的过滤器反序列化之后和 src 完全相同
+	dst, err := NewBloomFilterWithHasher[int](10, 0.5, mapx.IntegerHash[int])
+	assert.NoError(t, err)
+	assert.NoError(t, dst.UnmarshalBinary(data))
+	assert.Equal(t, src.Cap(), dst.Cap())
+	assert.Equal(t, src.HashCount(), dst.HashCount())
+	assert.Equal(t, src.bits, dst.bits)
+	for i := 0; i < 100; i++ {
+		assert.True(t, dst.Exist(i*7))
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: []byte{}},
+		{name: "short header", data: data[:bloomFilterHeaderSize-1]},
+		{name: "bad version", data: append([]byte{2}, data[1:]...)},
+		{name: "truncated bits", data: data[:len(data)-1]},
+		{name: "zero hash count", data: append([]byte{1, 0, 0, 0, 0}, data[5:]...)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBloomFilterWithHasher[int](10, 0.01, mapx.IntegerHash[int])
+			assert.NoError(t, err)
+			assert.Equal(t, errBloomFilterInvalidData, b.UnmarshalBinary(tc.data))
+			// 反序列化失败时不会修改过滤器
+			assert.Equal(t, uint64(128), b.Cap())
+		})
+	}
+}
+
+type testData struct {
+	id int
+}
+
+func (t testData) Code() uint64 {
+	return uint64(t.id % 10)
+}
+
+func (t testData) Equals(key any) bool {
+	val, ok := key.(testData)
+	return ok && val.id == t.id
+}
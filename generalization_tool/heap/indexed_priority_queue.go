@@ -0,0 +1,184 @@
+package heap
+
+import (
+	"errors"
+	"generalization_tool"
+)
+
+// ErrInvalidHandle 句柄不属于该堆，或对应的元素已经被移除
+var ErrInvalidHandle = errors.New("generalization_tool: 无效的堆句柄")
+
+// Handle 元素在 IndexedPriorityQueue 中的句柄，Push 时返回
+// 通过句柄可以在 O(log n) 内修改或移除已经入堆的元素
+type Handle[T any] struct {
+	value T
+	// index 元素在堆中的下标，元素被移除后为-1
+	index int
+	owner *IndexedPriorityQueue[T]
+}
+
+// Value 返回句柄对应的元素
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// IndexedPriorityQueue 支持按句柄修改优先级的二叉小顶堆，非线程安全
+// 适用于 Dijkstra 中的 decrease-key、定时器重置等场景
+type IndexedPriorityQueue[T any] struct {
+	data    []*Handle[T]
+	compare generalization_tool.Comparator[T]
+	// capacity 容量上限，小于等于0时表示无界
+	capacity int
+}
+
+// NewIndexedPriorityQueue 创建一个索引堆。capacity <= 0 时为无界堆；需注意比较器compare不能为nil
+func NewIndexedPriorityQueue[T any](capacity int, compare generalization_tool.Comparator[T]) (*IndexedPriorityQueue[T], error) {
+	if compare == nil {
+		return nil, ErrNilComparator
+	}
+	initCap := capacity
+	if initCap < 0 {
+		initCap = 0
+	}
+	return &IndexedPriorityQueue[T]{
+		data:     make([]*Handle[T], 0, initCap),
+		compare:  compare,
+		capacity: capacity,
+	}, nil
+}
+
+// Len 返回堆中元素的数量
+func (p *IndexedPriorityQueue[T]) Len() int {
+	return len(p.data)
+}
+
+// Cap 返回堆的容量上限，无界堆返回0
+func (p *IndexedPriorityQueue[T]) Cap() int {
+	if p.capacity <= 0 {
+		return 0
+	}
+	return p.capacity
+}
+
+// Push 放入元素并返回其句柄，有界堆已满时返回 ErrOutOfCapacity
+func (p *IndexedPriorityQueue[T]) Push(t T) (*Handle[T], error) {
+	if p.capacity > 0 && len(p.data) >= p.capacity {
+		return nil, ErrOutOfCapacity
+	}
+	h := &Handle[T]{
+		value: t,
+		index: len(p.data),
+		owner: p,
+	}
+	p.data = append(p.data, h)
+	p.up(h.index)
+	return h, nil
+}
+
+// Pop 取出堆顶元素，堆为空时返回 ErrEmptyHeap
+func (p *IndexedPriorityQueue[T]) Pop() (T, error) {
+	if len(p.data) == 0 {
+		var zero T
+		return zero, ErrEmptyHeap
+	}
+	return p.removeAt(0), nil
+}
+
+// Peek 返回堆顶元素但不取出，堆为空时返回 ErrEmptyHeap
+func (p *IndexedPriorityQueue[T]) Peek() (T, error) {
+	if len(p.data) == 0 {
+		var zero T
+		return zero, ErrEmptyHeap
+	}
+	return p.data[0].value, nil
+}
+
+// Contains 判断句柄对应的元素是否仍在堆中
+func (p *IndexedPriorityQueue[T]) Contains(h *Handle[T]) bool {
+	return h != nil && h.owner == p && h.index >= 0
+}
+
+// Update 修改句柄对应元素的值，并重新调整其在堆中的位置
+func (p *IndexedPriorityQueue[T]) Update(h *Handle[T], t T) error {
+	if !p.Contains(h) {
+		return ErrInvalidHandle
+	}
+	h.value = t
+	p.fix(h.index)
+	return nil
+}
+
+// Remove 移除句柄对应的元素，并返回该元素
+func (p *IndexedPriorityQueue[T]) Remove(h *Handle[T]) (T, error) {
+	if !p.Contains(h) {
+		var zero T
+		return zero, ErrInvalidHandle
+	}
+	return p.removeAt(h.index), nil
+}
+
+// removeAt 移除下标 i 的元素：将末尾元素移动到 i，再重新调整
+func (p *IndexedPriorityQueue[T]) removeAt(i int) T {
+	n := len(p.data) - 1
+	h := p.data[i]
+	if i != n {
+		p.swap(i, n)
+	}
+	p.data[n] = nil
+	p.data = p.data[:n]
+	if i != n {
+		p.fix(i)
+	}
+	h.index = -1
+	return h.value
+}
+
+// fix 元素的值发生变化后，先尝试上浮，上浮失败再尝试下沉
+func (p *IndexedPriorityQueue[T]) fix(i int) {
+	if !p.up(i) {
+		p.down(i)
+	}
+}
+
+func (p *IndexedPriorityQueue[T]) less(i, j int) bool {
+	return p.compare(p.data[i].value, p.data[j].value) < 0
+}
+
+func (p *IndexedPriorityQueue[T]) swap(i, j int) {
+	p.data[i], p.data[j] = p.data[j], p.data[i]
+	p.data[i].index = i
+	p.data[j].index = j
+}
+
+// up 上浮，返回元素是否发生了移动
+func (p *IndexedPriorityQueue[T]) up(i int) bool {
+	moved := false
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !p.less(i, parent) {
+			break
+		}
+		p.swap(i, parent)
+		i = parent
+		moved = true
+	}
+	return moved
+}
+
+func (p *IndexedPriorityQueue[T]) down(i int) {
+	n := len(p.data)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && p.less(l, smallest) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && p.less(r, smallest) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		p.swap(i, smallest)
+		i = smallest
+	}
+}
@@ -0,0 +1,152 @@
+package heap
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestNewIndexedPriorityQueue(t *testing.T) {
+	_, err := NewIndexedPriorityQueue[int](0, nil)
+	assert.ErrorIs(t, err, ErrNilComparator)
+
+	pq, err := NewIndexedPriorityQueue[int](2, compare())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pq.Cap())
+	_, err = pq.Peek()
+	assert.Equal(t, ErrEmptyHeap, err)
+	_, err = pq.Pop()
+	assert.Equal(t, ErrEmptyHeap, err)
+
+	_, err = pq.Push(1)
+	assert.NoError(t, err)
+	_, err = pq.Push(2)
+	assert.NoError(t, err)
+	_, err = pq.Push(3)
+	assert.Equal(t, ErrOutOfCapacity, err)
+}
+
+func TestIndexedPriorityQueue_Update(t *testing.T) {
+	testCases := []struct {
+		name    string
+		values  []int
+		target  int
+		newVal  int
+		wantRes []int
+	}{
+		{
+			name:    "decrease key",
+			values:  []int{5, 3, 8, 1, 9},
+			target:  4,
+			newVal:  0,
+			wantRes: []int{0, 1, 3, 5, 8},
+		},
+		{
+			name:    "increase key",
+			values:  []int{5, 3, 8, 1, 9},
+			target:  3,
+			newVal:  10,
+			wantRes: []int{3, 5, 8, 9, 10},
+		},
+		{
+			name:    "same key",
+			values:  []int{5, 3, 8},
+			target:  0,
+			newVal:  5,
+			wantRes: []int{3, 5, 8},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq, err := NewIndexedPriorityQueue[int](0, compare())
+			assert.NoError(t, err)
+			handles := make([]*Handle[int], 0, len(tc.values))
+			for _, v := range tc.values {
+				h, err := pq.Push(v)
+				assert.NoError(t, err)
+				handles = append(handles, h)
+			}
+			assert.NoError(t, pq.Update(handles[tc.target], tc.newVal))
+			assert.Equal(t, tc.newVal, handles[tc.target].Value())
+			assert.Equal(t, tc.wantRes, drainIndexed(pq))
+		})
+	}
+}
+
+func TestIndexedPriorityQueue_Remove(t *testing.T) {
+	pq, err := NewIndexedPriorityQueue[int](0, compare())
+	assert.NoError(t, err)
+	other, err := NewIndexedPriorityQueue[int](0, compare())
+	assert.NoError(t, err)
+
+	handles := make([]*Handle[int], 0, 5)
+	for _, v := range []int{5, 3, 8, 1, 9} {
+		h, err := pq.Push(v)
+		assert.NoError(t, err)
+		handles = append(handles, h)
+	}
+	foreign, err := other.Push(1)
+	assert.NoError(t, err)
+
+	assert.False(t, pq.Contains(nil))
+	assert.False(t, pq.Contains(foreign))
+	_, err = pq.Remove(foreign)
+	assert.Equal(t, ErrInvalidHandle, err)
+
+	val, err := pq.Remove(handles[1])
+	assert.NoError(t, err)
+	assert.Equal(t, 3, val)
+	assert.False(t, pq.Contains(handles[1]))
+	_, err = pq.Remove(handles[1])
+	assert.Equal(t, ErrInvalidHandle, err)
+	assert.Equal(t, ErrInvalidHandle, pq.Update(handles[1], 0))
+
+	top, err := pq.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, top)
+	assert.False(t, pq.Contains(handles[3]))
+	assert.True(t, pq.Contains(handles[0]))
+	assert.Equal(t, []int{5, 8, 9}, drainIndexed(pq))
+}
+
+// TestIndexedPriorityQueue_Random 随机操作与排序结果对比
+func TestIndexedPriorityQueue_Random(t *testing.T) {
+	pq, err := NewIndexedPriorityQueue[int](0, compare())
+	assert.NoError(t, err)
+	handles := make([]*Handle[int], 0, 1000)
+	for i := 0; i < 1000; i++ {
+		h, err := pq.Push(rand.Intn(10000))
+		assert.NoError(t, err)
+		handles = append(handles, h)
+	}
+	for i := 0; i < 500; i++ {
+		h := handles[rand.Intn(len(handles))]
+		if !pq.Contains(h) {
+			continue
+		}
+		if i%3 == 0 {
+			_, err = pq.Remove(h)
+		} else {
+			err = pq.Update(h, rand.Intn(10000))
+		}
+		assert.NoError(t, err)
+	}
+	want := make([]int, 0, pq.Len())
+	for _, h := range handles {
+		if pq.Contains(h) {
+			want = append(want, h.Value())
+		}
+	}
+	sort.Ints(want)
+	assert.Equal(t, want, drainIndexed(pq))
+}
+
+func drainIndexed[T any](pq *IndexedPriorityQueue[T]) []T {
+	res := make([]T, 0, pq.Len())
+	for pq.Len() > 0 {
+		t, _ := pq.Pop()
+		res = append(res, t)
+	}
+	return res
+}
@@ -0,0 +1,177 @@
+package heap
+
+import (
+	"errors"
+	"generalization_tool"
+)
+
+var (
+	// ErrEmptyHeap 堆为空
+	ErrEmptyHeap = errors.New("generalization_tool: 堆为空")
+	// ErrOutOfCapacity 超出堆的容量限制
+	ErrOutOfCapacity = errors.New("generalization_tool: 超出最大容量限制")
+	// ErrNilComparator 比较器为nil
+	ErrNilComparator = errors.New("Heap：Comparator不能为nil")
+
+	errHeapInvalidArity = errors.New("Heap：arity必须大于等于2")
+)
+
+// PriorityQueue 基于 d 叉小顶堆实现的优先队列，非线程安全
+// 堆顶永远是 compare 意义下的最小元素；需要大顶堆时，传入相反的比较器即可
+// - 二叉堆比较次数更少，适合 Pop 较多的场景
+// - 四叉堆树高更低、访问更连续，适合 Push 较多的场景
+type PriorityQueue[T any] struct {
+	data    []T
+	compare generalization_tool.Comparator[T]
+	// capacity 容量上限，小于等于0时表示无界
+	capacity int
+	arity    int
+}
+
+// NewPriorityQueue 创建一个二叉堆。capacity <= 0 时为无界堆；需注意比较器compare不能为nil
+func NewPriorityQueue[T any](capacity int, compare generalization_tool.Comparator[T]) (*PriorityQueue[T], error) {
+	return NewDaryPriorityQueue[T](2, capacity, compare)
+}
+
+// NewDaryPriorityQueue 创建一个 arity 叉堆，arity 必须大于等于2。capacity <= 0 时为无界堆
+func NewDaryPriorityQueue[T any](arity int, capacity int, compare generalization_tool.Comparator[T]) (*PriorityQueue[T], error) {
+	if compare == nil {
+		return nil, ErrNilComparator
+	}
+	if arity < 2 {
+		return nil, errHeapInvalidArity
+	}
+	initCap := capacity
+	if initCap < 0 {
+		initCap = 0
+	}
+	return &PriorityQueue[T]{
+		data:     make([]T, 0, initCap),
+		compare:  compare,
+		capacity: capacity,
+		arity:    arity,
+	}, nil
+}
+
+// NewPriorityQueueOf 使用 values 在 O(n) 时间内建立一个二叉堆，堆会直接使用并修改 values
+// 如果 capacity > 0 且 values 的长度超过 capacity，那么返回 ErrOutOfCapacity
+func NewPriorityQueueOf[T any](capacity int, values []T, compare generalization_tool.Comparator[T]) (*PriorityQueue[T], error) {
+	return NewDaryPriorityQueueOf[T](2, capacity, values, compare)
+}
+
+// NewDaryPriorityQueueOf 使用 values 在 O(n) 时间内建立一个 arity 叉堆，堆会直接使用并修改 values
+// arity 必须大于等于2；如果 capacity > 0 且 values 的长度超过 capacity，那么返回 ErrOutOfCapacity
+func NewDaryPriorityQueueOf[T any](arity int, capacity int, values []T, compare generalization_tool.Comparator[T]) (*PriorityQueue[T], error) {
+	pq, err := NewDaryPriorityQueue[T](arity, 0, compare)
+	if err != nil {
+		return nil, err
+	}
+	if capacity > 0 && len(values) > capacity {
+		return nil, ErrOutOfCapacity
+	}
+	pq.capacity = capacity
+	pq.data = values
+	if pq.data == nil {
+		pq.data = make([]T, 0)
+	}
+	pq.heapify()
+	return pq, nil
+}
+
+// Len 返回堆中元素的数量
+func (p *PriorityQueue[T]) Len() int {
+	return len(p.data)
+}
+
+// Cap 返回堆的容量上限，无界堆返回0
+func (p *PriorityQueue[T]) Cap() int {
+	if p.capacity <= 0 {
+		return 0
+	}
+	return p.capacity
+}
+
+// IsBoundless 是否为无界堆
+func (p *PriorityQueue[T]) IsBoundless() bool {
+	return p.capacity <= 0
+}
+
+// Push 放入元素，有界堆已满时返回 ErrOutOfCapacity
+func (p *PriorityQueue[T]) Push(t T) error {
+	if !p.IsBoundless() && len(p.data) >= p.capacity {
+		return ErrOutOfCapacity
+	}
+	p.data = append(p.data, t)
+	p.up(len(p.data) - 1)
+	return nil
+}
+
+// Pop 取出堆顶元素，堆为空时返回 ErrEmptyHeap
+func (p *PriorityQueue[T]) Pop() (T, error) {
+	var zero T
+	if len(p.data) == 0 {
+		return zero, ErrEmptyHeap
+	}
+	n := len(p.data) - 1
+	top := p.data[0]
+	p.data[0] = p.data[n]
+	// 释放对元素的引用，方便 GC
+	p.data[n] = zero
+	p.data = p.data[:n]
+	if n > 0 {
+		p.down(0)
+	}
+	return top, nil
+}
+
+// Peek 返回堆顶元素但不取出，堆为空时返回 ErrEmptyHeap
+func (p *PriorityQueue[T]) Peek() (T, error) {
+	if len(p.data) == 0 {
+		var zero T
+		return zero, ErrEmptyHeap
+	}
+	return p.data[0], nil
+}
+
+// AsSlice 返回堆中的全部元素，顺序为堆的内部顺序而非有序；每次调用都返回一个新切片
+func (p *PriorityQueue[T]) AsSlice() []T {
+	res := make([]T, len(p.data))
+	copy(res, p.data)
+	return res
+}
+
+// heapify 自底向上建堆，从最后一个非叶子节点开始下沉
+func (p *PriorityQueue[T]) heapify() {
+	for i := (len(p.data) - 2) / p.arity; i >= 0; i-- {
+		p.down(i)
+	}
+}
+
+func (p *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / p.arity
+		if p.compare(p.data[i], p.data[parent]) >= 0 {
+			return
+		}
+		p.data[i], p.data[parent] = p.data[parent], p.data[i]
+		i = parent
+	}
+}
+
+func (p *PriorityQueue[T]) down(i int) {
+	n := len(p.data)
+	for {
+		smallest := i
+		first := p.arity*i + 1
+		for c := first; c < first+p.arity && c < n; c++ {
+			if p.compare(p.data[c], p.data[smallest]) < 0 {
+				smallest = c
+			}
+		}
+		if smallest == i {
+			return
+		}
+		p.data[i], p.data[smallest] = p.data[smallest], p.data[i]
+		i = smallest
+	}
+}
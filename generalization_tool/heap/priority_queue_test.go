@@ -0,0 +1,286 @@
+package heap
+
+import (
+	"container/heap"
+	"generalization_tool"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestNewDaryPriorityQueue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		arity    int
+		capacity int
+		compare  generalization_tool.Comparator[int]
+		wantCap  int
+		wantErr  error
+	}{
+		{
+			name:     "nil comparator",
+			arity:    2,
+			capacity: 10,
+			wantErr:  ErrNilComparator,
+		},
+		{
+			name:     "invalid arity",
+			arity:    1,
+			capacity: 10,
+			compare:  compare(),
+			wantErr:  errHeapInvalidArity,
+		},
+		{
+			name:     "bounded",
+			arity:    4,
+			capacity: 10,
+			compare:  compare(),
+			wantCap:  10,
+		},
+		{
+			name:     "boundless",
+			arity:    2,
+			capacity: -1,
+			compare:  compare(),
+			wantCap:  0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq, err := NewDaryPriorityQueue[int](tc.arity, tc.capacity, tc.compare)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantCap, pq.Cap())
+			assert.Equal(t, tc.capacity <= 0, pq.IsBoundless())
+			assert.Equal(t, 0, pq.Len())
+		})
+	}
+}
+
+func TestNewPriorityQueueOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capacity int
+		values   []int
+		wantRes  []int
+		wantErr  error
+	}{
+		{
+			name:    "nil",
+			values:  nil,
+			wantRes: []int{},
+		},
+		{
+			name:     "out of capacity",
+			capacity: 2,
+			values:   []int{3, 2, 1},
+			wantErr:  ErrOutOfCapacity,
+		},
+		{
+			name:     "normal",
+			capacity: 5,
+			values:   []int{5, 3, 4, 1, 2},
+			wantRes:  []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:    "duplicate",
+			values:  []int{2, 1, 2, 1, 3},
+			wantRes: []int{1, 1, 2, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq, err := NewPriorityQueueOf[int](tc.capacity, tc.values, compare())
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantRes, drain(pq))
+		})
+	}
+}
+
+func TestNewDaryPriorityQueueOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		arity    int
+		capacity int
+		values   []int
+		wantRes  []int
+		wantErr  error
+	}{
+		{
+			name:    "invalid arity",
+			arity:   1,
+			values:  []int{1},
+			wantErr: errHeapInvalidArity,
+		},
+		{
+			name:     "out of capacity",
+			arity:    4,
+			capacity: 2,
+			values:   []int{3, 2, 1},
+			wantErr:  ErrOutOfCapacity,
+		},
+		{
+			name:    "4-ary",
+			arity:   4,
+			values:  []int{9, 3, 7, 1, 8, 2, 6, 4, 5, 0, 11, 10},
+			wantRes: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		{
+			name:    "3-ary duplicate",
+			arity:   3,
+			values:  []int{2, 1, 2, 1, 3},
+			wantRes: []int{1, 1, 2, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq, err := NewDaryPriorityQueueOf[int](tc.arity, tc.capacity, tc.values, compare())
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.arity, pq.arity)
+			// heapify 之后每个节点都不大于它的 arity 个子节点
+			for i := 1; i < len(pq.data); i++ {
+				assert.LessOrEqual(t, pq.data[(i-1)/tc.arity], pq.data[i])
+			}
+			assert.Equal(t, tc.wantRes, drain(pq))
+		})
+	}
+}
+
+func TestPriorityQueue_Push(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capacity int
+		values   []int
+		value    int
+		wantErr  error
+		wantRes  []int
+	}{
+		{
+			name:    "empty",
+			value:   1,
+			wantRes: []int{1},
+		},
+		{
+			name:     "bounded not full",
+			capacity: 3,
+			values:   []int{3, 1},
+			value:    2,
+			wantRes:  []int{1, 2, 3},
+		},
+		{
+			name:     "bounded full",
+			capacity: 2,
+			values:   []int{3, 1},
+			value:    2,
+			wantErr:  ErrOutOfCapacity,
+			wantRes:  []int{1, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq, err := NewPriorityQueue[int](tc.capacity, compare())
+			assert.NoError(t, err)
+			for _, v := range tc.values {
+				assert.NoError(t, pq.Push(v))
+			}
+			assert.Equal(t, tc.wantErr, pq.Push(tc.value))
+			assert.Equal(t, tc.wantRes, drain(pq))
+		})
+	}
+}
+
+func TestPriorityQueue_PopPeek(t *testing.T) {
+	for _, arity := range []int{2, 3, 4} {
+		pq, err := NewDaryPriorityQueue[int](arity, 0, compare())
+		assert.NoError(t, err)
+		_, err = pq.Peek()
+		assert.Equal(t, ErrEmptyHeap, err)
+		_, err = pq.Pop()
+		assert.Equal(t, ErrEmptyHeap, err)
+
+		values := rand.Perm(500)
+		for _, v := range values {
+			assert.NoError(t, pq.Push(v))
+		}
+		assert.Equal(t, len(values), pq.Len())
+		assert.ElementsMatch(t, values, pq.AsSlice())
+		sort.Ints(values)
+		for _, want := range values {
+			top, err := pq.Peek()
+			assert.NoError(t, err)
+			assert.Equal(t, want, top)
+			top, err = pq.Pop()
+			assert.NoError(t, err)
+			assert.Equal(t, want, top)
+		}
+		assert.Equal(t, 0, pq.Len())
+	}
+}
+
+// BenchmarkPriorityQueue 对比二叉堆、四叉堆和 container/heap
+func BenchmarkPriorityQueue(b *testing.B) {
+	values := rand.Perm(1 << 16)
+	b.Run("binary", func(b *testing.B) {
+		pq, _ := NewPriorityQueue[int](0, compare())
+		for i := 0; i < b.N; i++ {
+			_ = pq.Push(values[i&(len(values)-1)])
+			if pq.Len() > 1024 {
+				_, _ = pq.Pop()
+			}
+		}
+	})
+	b.Run("4-ary", func(b *testing.B) {
+		pq, _ := NewDaryPriorityQueue[int](4, 0, compare())
+		for i := 0; i < b.N; i++ {
+			_ = pq.Push(values[i&(len(values)-1)])
+			if pq.Len() > 1024 {
+				_, _ = pq.Pop()
+			}
+		}
+	})
+	b.Run("container/heap", func(b *testing.B) {
+		h := &intHeap{}
+		for i := 0; i < b.N; i++ {
+			heap.Push(h, values[i&(len(values)-1)])
+			if h.Len() > 1024 {
+				heap.Pop(h)
+			}
+		}
+	})
+}
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+func drain[T any](pq *PriorityQueue[T]) []T {
+	res := make([]T, 0, pq.Len())
+	for pq.Len() > 0 {
+		t, _ := pq.Pop()
+		res = append(res, t)
+	}
+	return res
+}
+
+func compare() generalization_tool.Comparator[int] {
+	return generalization_tool.ComparatorRealNumber[int]
+}
@@ -30,3 +30,9 @@ func calculateCap(capacity int, length int) (int, bool) {
 	}
 	return capacity, false
 }
+
+// CalculateShrinkCap 按照 Shrink 的规则计算缩容后的容量，bool 表示是否需要缩容
+// 用于不直接基于切片扩缩容的结构，例如环形缓冲区
+func CalculateShrinkCap(capacity int, length int) (int, bool) {
+	return calculateCap(capacity, length)
+}
@@ -0,0 +1,68 @@
+package tree
+
+// Iterator 红黑树的迭代器，按照中序遍历的顺序（或者逆序）访问节点
+// 用法：
+//
+//	for it := rb.Ascend(); it.Next(); {
+//		key, value := it.Key(), it.Value()
+//	}
+//
+// 迭代过程中不能修改红黑树，否则结果是未定义的
+type Iterator[K any, V any] struct {
+	tree       *RBTree[K, V]
+	cur        *rbNode[K, V]
+	next       *rbNode[K, V]
+	descending bool
+}
+
+// Ascend 返回从最小的节点开始、按照 key 从小到大遍历的迭代器
+func (rb *RBTree[K, V]) Ascend() *Iterator[K, V] {
+	return rb.newIterator(rb.minNode(rb.root), false)
+}
+
+// AscendFrom 返回从大于（inclusive 为 true 时大于等于）key 的最小节点开始、按照 key 从小到大遍历的迭代器
+func (rb *RBTree[K, V]) AscendFrom(key K, inclusive bool) *Iterator[K, V] {
+	return rb.newIterator(rb.ceilingNode(key, inclusive), false)
+}
+
+// Descend 返回从最大的节点开始、按照 key 从大到小遍历的迭代器
+func (rb *RBTree[K, V]) Descend() *Iterator[K, V] {
+	return rb.newIterator(rb.maxNode(rb.root), true)
+}
+
+// DescendFrom 返回从小于（inclusive 为 true 时小于等于）key 的最大节点开始、按照 key 从大到小遍历的迭代器
+func (rb *RBTree[K, V]) DescendFrom(key K, inclusive bool) *Iterator[K, V] {
+	return rb.newIterator(rb.floorNode(key, inclusive), true)
+}
+
+func (rb *RBTree[K, V]) newIterator(first *rbNode[K, V], descending bool) *Iterator[K, V] {
+	return &Iterator[K, V]{
+		tree:       rb,
+		next:       first,
+		descending: descending,
+	}
+}
+
+// Next 移动到下一个节点，没有更多节点时返回false
+func (it *Iterator[K, V]) Next() bool {
+	it.cur = it.next
+	if it.cur == nil {
+		return false
+	}
+	if it.descending {
+		it.next = it.tree.findPredecessor(it.cur)
+	} else {
+		it.next = it.tree.findSuccessor(it.cur)
+	}
+	return true
+}
+
+// Key 返回当前节点的 key，必须在 Next 返回 true 之后调用
+func (it *Iterator[K, V]) Key() K {
+	return it.cur.key
+}
+
+// Value 返回当前节点的 value，必须在 Next 返回 true 之后调用
+func (it *Iterator[K, V]) Value() V {
+	return it.cur.value
+}
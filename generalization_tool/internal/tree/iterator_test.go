@@ -0,0 +1,89 @@
+package tree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	rbTree := NewRBTree[int, int](compare())
+	for _, k := range []int{5, 2, 8, 1, 3, 7, 9, 4, 6} {
+		assert.NoError(t, rbTree.Add(k, k*10))
+	}
+	testCases := []struct {
+		name     string
+		it       *Iterator[int, int]
+		wantKeys []int
+	}{
+		{
+			name:     "ascend",
+			it:       rbTree.Ascend(),
+			wantKeys: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "ascend from inclusive",
+			it:       rbTree.AscendFrom(7, true),
+			wantKeys: []int{7, 8, 9},
+		},
+		{
+			name:     "ascend from exclusive",
+			it:       rbTree.AscendFrom(7, false),
+			wantKeys: []int{8, 9},
+		},
+		{
+			name:     "ascend from key not exist",
+			it:       rbTree.AscendFrom(0, false),
+			wantKeys: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "ascend from max",
+			it:       rbTree.AscendFrom(9, false),
+			wantKeys: []int{},
+		},
+		{
+			name:     "descend",
+			it:       rbTree.Descend(),
+			wantKeys: []int{9, 8, 7, 6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:     "descend from inclusive",
+			it:       rbTree.DescendFrom(3, true),
+			wantKeys: []int{3, 2, 1},
+		},
+		{
+			name:     "descend from exclusive",
+			it:       rbTree.DescendFrom(3, false),
+			wantKeys: []int{2, 1},
+		},
+		{
+			name:     "descend from key not exist",
+			it:       rbTree.DescendFrom(100, true),
+			wantKeys: []int{9, 8, 7, 6, 5, 4, 3, 2, 1},
+		},
+		{
+			name:     "descend from min",
+			it:       rbTree.DescendFrom(1, false),
+			wantKeys: []int{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := make([]int, 0)
+			for tc.it.Next() {
+				assert.Equal(t, tc.it.Key()*10, tc.it.Value())
+				keys = append(keys, tc.it.Key())
+			}
+			assert.Equal(t, tc.wantKeys, keys)
+			// 遍历结束之后继续调用 Next 仍然返回false
+			assert.False(t, tc.it.Next())
+		})
+	}
+}
+
+func TestIterator_Empty(t *testing.T) {
+	rbTree := NewRBTree[int, int](compare())
+	assert.False(t, rbTree.Ascend().Next())
+	assert.False(t, rbTree.Descend().Next())
+	assert.False(t, rbTree.AscendFrom(1, true).Next())
+	assert.False(t, rbTree.DescendFrom(1, true).Next())
+}
@@ -18,9 +18,11 @@ var (
 )
 
 type rbNode[K any, V any] struct {
-	key    K
-	value  V
-	color  color
+	key   K
+	value V
+	color color
+	// size 以当前节点为根的子树的节点数量，用于 Rank 和 Select
+	size   int
 	left   *rbNode[K, V]
 	right  *rbNode[K, V]
 	parent *rbNode[K, V]
@@ -31,6 +33,7 @@ func newRBNode[K any, V any](key K, value V) *rbNode[K, V] {
 		key:    key,
 		value:  value,
 		color:  Red,
+		size:   1,
 		left:   nil,
 		right:  nil,
 		parent: nil,
@@ -44,6 +47,18 @@ func (n *rbNode[K, V]) setNode(v V) {
 	n.value = v
 }
 
+// Bound 区间的一个端点，零值表示这一侧没有边界
+type Bound[K any] struct {
+	key       K
+	inclusive bool
+	bounded   bool
+}
+
+// NewBound 创建一个端点，inclusive 表示区间是否包含 key
+func NewBound[K any](key K, inclusive bool) Bound[K] {
+	return Bound[K]{key: key, inclusive: inclusive, bounded: true}
+}
+
 type RBTree[K any, V any] struct {
 	root    *rbNode[K, V]
 	compare generalization_tool.Comparator[K]
@@ -104,13 +119,140 @@ func (rb *RBTree[K, V]) KeyValues() ([]K, []V) {
 	if rb.root == nil {
 		return keys, values
 	}
-	rb.inOrderTraversal(func(n *rbNode[K, V]) {
+	rb.inOrderTraversal(func(n *rbNode[K, V]) bool {
 		keys = append(keys, n.key)
 		values = append(values, n.value)
+		return true
 	})
 	return keys, values
 }
 
+// Range 按照 key 从小到大的顺序遍历所有节点，fn 返回 false 时停止遍历
+func (rb *RBTree[K, V]) Range(fn func(key K, value V) bool) {
+	rb.inOrderTraversal(func(n *rbNode[K, V]) bool {
+		return fn(n.key, n.value)
+	})
+}
+
+// Clear 删除所有节点
+func (rb *RBTree[K, V]) Clear() {
+	rb.root = nil
+	rb.size = 0
+}
+
+// Floor 返回小于等于 key 的最大节点
+func (rb *RBTree[K, V]) Floor(key K) (K, V, bool) {
+	return rb.nodeKeyValue(rb.floorNode(key, true))
+}
+
+// Lower 返回严格小于 key 的最大节点
+func (rb *RBTree[K, V]) Lower(key K) (K, V, bool) {
+	return rb.nodeKeyValue(rb.floorNode(key, false))
+}
+
+// Ceiling 返回大于等于 key 的最小节点
+func (rb *RBTree[K, V]) Ceiling(key K) (K, V, bool) {
+	return rb.nodeKeyValue(rb.ceilingNode(key, true))
+}
+
+// Higher 返回严格大于 key 的最小节点
+func (rb *RBTree[K, V]) Higher(key K) (K, V, bool) {
+	return rb.nodeKeyValue(rb.ceilingNode(key, false))
+}
+
+// AscendRange 按照 key 从小到大的顺序遍历区间 [lo, hi] 内的节点，fn 返回 false 时停止遍历
+// 先找到区间的第一个节点，再沿着后继节点遍历，时间复杂度是 O(log n + k)
+func (rb *RBTree[K, V]) AscendRange(lo Bound[K], hi Bound[K], fn func(key K, value V) bool) {
+	n := rb.minNode(rb.root)
+	if lo.bounded {
+		n = rb.ceilingNode(lo.key, lo.inclusive)
+	}
+	for ; n != nil && rb.belowHigh(n.key, hi); n = rb.findSuccessor(n) {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// InRange 判断 key 是否在区间 [lo, hi] 内
+func (rb *RBTree[K, V]) InRange(key K, lo Bound[K], hi Bound[K]) bool {
+	return rb.aboveLow(key, lo) && rb.belowHigh(key, hi)
+}
+
+func (rb *RBTree[K, V]) aboveLow(key K, lo Bound[K]) bool {
+	if !lo.bounded {
+		return true
+	}
+	cmp := rb.compare(key, lo.key)
+	return cmp > 0 || (cmp == 0 && lo.inclusive)
+}
+
+func (rb *RBTree[K, V]) belowHigh(key K, hi Bound[K]) bool {
+	if !hi.bounded {
+		return true
+	}
+	cmp := rb.compare(key, hi.key)
+	return cmp < 0 || (cmp == 0 && hi.inclusive)
+}
+
+// Rank 返回小于 key 的节点数量，key 不需要存在于树中，时间复杂度 O(log n)
+func (rb *RBTree[K, V]) Rank(key K) int {
+	rank := 0
+	n := rb.root
+	for n != nil {
+		cmp := rb.compare(key, n.key)
+		if cmp < 0 {
+			n = n.left
+		} else if cmp > 0 {
+			// n 和 n 的左子树都小于 key
+			rank += n.left.getSize() + 1
+			n = n.right
+		} else {
+			return rank + n.left.getSize()
+		}
+	}
+	return rank
+}
+
+// Select 返回第 i 小的节点（从0开始），i 越界时返回false，时间复杂度 O(log n)
+func (rb *RBTree[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= rb.size {
+		return rb.nodeKeyValue(nil)
+	}
+	n := rb.root
+	for n != nil {
+		leftSize := n.left.getSize()
+		if i < leftSize {
+			n = n.left
+		} else if i > leftSize {
+			i -= leftSize + 1
+			n = n.right
+		} else {
+			break
+		}
+	}
+	return rb.nodeKeyValue(n)
+}
+
+// Min 返回 key 最小的节点
+func (rb *RBTree[K, V]) Min() (K, V, bool) {
+	return rb.nodeKeyValue(rb.minNode(rb.root))
+}
+
+// Max 返回 key 最大的节点
+func (rb *RBTree[K, V]) Max() (K, V, bool) {
+	return rb.nodeKeyValue(rb.maxNode(rb.root))
+}
+
+func (rb *RBTree[K, V]) nodeKeyValue(n *rbNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
 func (rb *RBTree[K, V]) addNode(n *rbNode[K, V]) error {
 	var fixNode *rbNode[K, V]
 	if rb.root == nil {
@@ -135,6 +277,7 @@ func (rb *RBTree[K, V]) addNode(n *rbNode[K, V]) error {
 			key:    n.key,
 			value:  n.value,
 			color:  Red,
+			size:   1,
 			parent: parent,
 		}
 		if cmp < 0 {
@@ -142,6 +285,10 @@ func (rb *RBTree[K, V]) addNode(n *rbNode[K, V]) error {
 		} else {
 			parent.right = fixNode
 		}
+		// 新节点的所有祖先的子树大小加1
+		for p := parent; p != nil; p = p.parent {
+			p.size++
+		}
 	}
 	rb.size++
 	rb.fixAfterAdd(fixNode)
@@ -235,6 +382,8 @@ func (rb *RBTree[K, V]) deleteNode(target *rbNode[K, V]) {
 		replacedNode = n.right
 	}
 	if replacedNode != nil {
+		// n 被移除，它的所有祖先的子树大小减1
+		rb.decreaseSize(n.parent)
 		replacedNode.parent = n.parent
 		if n.parent == nil {
 			rb.root = replacedNode
@@ -256,6 +405,8 @@ func (rb *RBTree[K, V]) deleteNode(target *rbNode[K, V]) {
 		if n.getColor() {
 			rb.fixAfterDelete(n)
 		}
+		// 旋转之后 n 的祖先可能发生变化，所以在旋转之后再更新子树大小
+		rb.decreaseSize(n.parent)
 		if n.parent != nil {
 			if n == n.parent.left {
 				n.parent.left = nil
@@ -268,6 +419,13 @@ func (rb *RBTree[K, V]) deleteNode(target *rbNode[K, V]) {
 	rb.size--
 }
 
+// decreaseSize 将 n 以及 n 的所有祖先的子树大小减1
+func (rb *RBTree[K, V]) decreaseSize(n *rbNode[K, V]) {
+	for ; n != nil; n = n.parent {
+		n.size--
+	}
+}
+
 // findSuccessor 寻找后继节点。后继节点是大于要删除节点的最小节点
 // case1: node节点存在右子节点,则右子树的最小节点是node的后继节点
 // case2: node节点不存在右子节点,则其第一个为左节点的祖先的父节点为node的后继节点
@@ -295,6 +453,25 @@ func (rb *RBTree[K, V]) findSuccessor(n *rbNode[K, V]) *rbNode[K, V] {
 	}
 }
 
+// findPredecessor 寻找前驱节点。前驱节点是小于 node 的最大节点，与 findSuccessor 对称
+// case1: node节点存在左子节点,则左子树的最大节点是node的前驱节点
+// case2: node节点不存在左子节点,则其第一个为右节点的祖先的父节点为node的前驱节点
+func (rb *RBTree[K, V]) findPredecessor(n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	if n.left != nil {
+		return rb.maxNode(n.left)
+	}
+	p := n.parent
+	ch := n
+	for p != nil && ch == p.left {
+		ch = p
+		p = p.parent
+	}
+	return p
+}
+
 // fixAfterDelete 删除时着色旋转
 // 根据x是节点位置分为fixAfterDeleteLeft,fixAfterDeleteRight两种情况
 func (rb *RBTree[K, V]) fixAfterDelete(n *rbNode[K, V]) {
@@ -396,7 +573,69 @@ func (rb *RBTree[K, V]) findNode(key K) *rbNode[K, V] {
 	return nil
 }
 
-func (rb *RBTree[K, V]) inOrderTraversal(visit func(node *rbNode[K, V])) {
+// floorNode 从根节点向下查找，返回小于（inclusive 为 true 时小于等于）key 的最大节点
+// 每次向右走时记录当前节点，最后一次记录的就是结果
+func (rb *RBTree[K, V]) floorNode(key K, inclusive bool) *rbNode[K, V] {
+	var res *rbNode[K, V]
+	n := rb.root
+	for n != nil {
+		cmp := rb.compare(key, n.key)
+		if cmp == 0 && inclusive {
+			return n
+		}
+		if cmp > 0 {
+			res = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return res
+}
+
+// ceilingNode 从根节点向下查找，返回大于（inclusive 为 true 时大于等于）key 的最小节点
+func (rb *RBTree[K, V]) ceilingNode(key K, inclusive bool) *rbNode[K, V] {
+	var res *rbNode[K, V]
+	n := rb.root
+	for n != nil {
+		cmp := rb.compare(key, n.key)
+		if cmp == 0 && inclusive {
+			return n
+		}
+		if cmp < 0 {
+			res = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return res
+}
+
+// minNode 返回以 n 为根的子树中最小的节点
+func (rb *RBTree[K, V]) minNode(n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// maxNode 返回以 n 为根的子树中最大的节点
+func (rb *RBTree[K, V]) maxNode(n *rbNode[K, V]) *rbNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// inOrderTraversal 中序遍历，visit 返回 false 时停止遍历
+func (rb *RBTree[K, V]) inOrderTraversal(visit func(node *rbNode[K, V]) bool) {
 	//1.创建一个栈 stack 来模拟递归调用栈，并初始化为空。
 	stackArea := make([]*rbNode[K, V], 0, rb.size)
 	//2.初始化当前节点为红黑树的根节点 rb.root。
@@ -409,7 +648,9 @@ func (rb *RBTree[K, V]) inOrderTraversal(visit func(node *rbNode[K, V])) {
 		}
 		cur = stackArea[len(stackArea)-1]
 		stackArea = stackArea[:len(stackArea)-1]
-		visit(cur)
+		if !visit(cur) {
+			return
+		}
 		cur = cur.right
 	}
 }
@@ -436,6 +677,9 @@ func (rb *RBTree[K, V]) leftRotate(n *rbNode[K, V]) {
 
 	r.left = n
 	n.parent = r
+
+	r.size = n.size
+	n.size = n.left.getSize() + n.right.getSize() + 1
 }
 
 func (rb *RBTree[K, V]) rightRotate(n *rbNode[K, V]) {
@@ -459,6 +703,16 @@ func (rb *RBTree[K, V]) rightRotate(n *rbNode[K, V]) {
 
 	l.right = n
 	n.parent = l
+
+	l.size = n.size
+	n.size = n.left.getSize() + n.right.getSize() + 1
+}
+
+func (n *rbNode[K, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
 }
 
 func (n *rbNode[K, V]) getColor() color {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"generalization_tool"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"testing"
 )
 
@@ -443,6 +444,250 @@ func TestRBTree_KeyValues(t *testing.T) {
 	}
 }
 
+func TestRBTree_Range(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     []int
+		stopAt   int
+		wantKeys []int
+	}{
+		{
+			name:     "nil",
+			keys:     nil,
+			stopAt:   -1,
+			wantKeys: []int{},
+		},
+		{
+			name:     "all",
+			keys:     []int{7, 4, 9, 5, 6, 8},
+			stopAt:   -1,
+			wantKeys: []int{4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "stop",
+			keys:     []int{7, 4, 9, 5, 6, 8},
+			stopAt:   6,
+			wantKeys: []int{4, 5, 6},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rbTree := NewRBTree[int, int](compare())
+			for _, k := range tc.keys {
+				assert.NoError(t, rbTree.Add(k, k*10))
+			}
+			keys := make([]int, 0)
+			rbTree.Range(func(key int, value int) bool {
+				assert.Equal(t, key*10, value)
+				keys = append(keys, key)
+				return key != tc.stopAt
+			})
+			assert.Equal(t, tc.wantKeys, keys)
+		})
+	}
+}
+
+func TestRBTree_Navigable(t *testing.T) {
+	rbTree := NewRBTree[int, int](compare())
+	_, _, ok := rbTree.Min()
+	assert.False(t, ok)
+	_, _, ok = rbTree.Max()
+	assert.False(t, ok)
+	_, _, ok = rbTree.Floor(1)
+	assert.False(t, ok)
+	// 10, 20, ..., 100
+	for _, k := range []int{50, 20, 80, 10, 30, 60, 90, 40, 70, 100} {
+		assert.NoError(t, rbTree.Add(k, k*10))
+	}
+	testCases := []struct {
+		name     string
+		find     func(key int) (int, int, bool)
+		key      int
+		wantKey  int
+		wantBool bool
+	}{
+		{name: "floor equal", find: rbTree.Floor, key: 30, wantKey: 30, wantBool: true},
+		{name: "floor between", find: rbTree.Floor, key: 35, wantKey: 30, wantBool: true},
+		{name: "floor too small", find: rbTree.Floor, key: 5},
+		{name: "floor too large", find: rbTree.Floor, key: 500, wantKey: 100, wantBool: true},
+		{name: "lower equal", find: rbTree.Lower, key: 30, wantKey: 20, wantBool: true},
+		{name: "lower between", find: rbTree.Lower, key: 35, wantKey: 30, wantBool: true},
+		{name: "lower min", find: rbTree.Lower, key: 10},
+		{name: "ceiling equal", find: rbTree.Ceiling, key: 60, wantKey: 60, wantBool: true},
+		{name: "ceiling between", find: rbTree.Ceiling, key: 55, wantKey: 60, wantBool: true},
+		{name: "ceiling too large", find: rbTree.Ceiling, key: 101},
+		{name: "ceiling too small", find: rbTree.Ceiling, key: -1, wantKey: 10, wantBool: true},
+		{name: "higher equal", find: rbTree.Higher, key: 60, wantKey: 70, wantBool: true},
+		{name: "higher between", find: rbTree.Higher, key: 55, wantKey: 60, wantBool: true},
+		{name: "higher max", find: rbTree.Higher, key: 100},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, value, ok := tc.find(tc.key)
+			assert.Equal(t, tc.wantBool, ok)
+			assert.Equal(t, tc.wantKey, key)
+			assert.Equal(t, tc.wantKey*10, value)
+		})
+	}
+	key, value, ok := rbTree.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 10, key)
+	assert.Equal(t, 100, value)
+	key, value, ok = rbTree.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 100, key)
+	assert.Equal(t, 1000, value)
+}
+
+func TestRBTree_AscendRange(t *testing.T) {
+	rbTree := NewRBTree[int, int](compare())
+	for _, k := range []int{5, 2, 8, 1, 3, 7, 9, 4, 6} {
+		assert.NoError(t, rbTree.Add(k, k))
+	}
+	testCases := []struct {
+		name     string
+		lo       Bound[int]
+		hi       Bound[int]
+		stopAt   int
+		wantKeys []int
+	}{
+		{
+			name:     "unbounded",
+			wantKeys: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "inclusive",
+			lo:       NewBound(3, true),
+			hi:       NewBound(6, true),
+			wantKeys: []int{3, 4, 5, 6},
+		},
+		{
+			name:     "exclusive",
+			lo:       NewBound(3, false),
+			hi:       NewBound(6, false),
+			wantKeys: []int{4, 5},
+		},
+		{
+			name:     "key not exist",
+			lo:       NewBound(0, false),
+			hi:       NewBound(100, false),
+			wantKeys: []int{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "head",
+			hi:       NewBound(3, false),
+			wantKeys: []int{1, 2},
+		},
+		{
+			name:     "tail",
+			lo:       NewBound(7, true),
+			wantKeys: []int{7, 8, 9},
+		},
+		{
+			name:     "empty",
+			lo:       NewBound(6, true),
+			hi:       NewBound(3, true),
+			wantKeys: []int{},
+		},
+		{
+			name:     "stop",
+			lo:       NewBound(2, true),
+			stopAt:   4,
+			wantKeys: []int{2, 3, 4},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := make([]int, 0)
+			rbTree.AscendRange(tc.lo, tc.hi, func(key int, value int) bool {
+				keys = append(keys, key)
+				return key != tc.stopAt
+			})
+			assert.Equal(t, tc.wantKeys, keys)
+		})
+	}
+}
+
+func TestRBTree_InRange(t *testing.T) {
+	rbTree := NewRBTree[int, int](compare())
+	testCases := []struct {
+		name string
+		key  int
+		lo   Bound[int]
+		hi   Bound[int]
+		want bool
+	}{
+		{name: "unbounded", key: 100, want: true},
+		{name: "inclusive low", key: 1, lo: NewBound(1, true), want: true},
+		{name: "exclusive low", key: 1, lo: NewBound(1, false), want: false},
+		{name: "below low", key: 0, lo: NewBound(1, true), want: false},
+		{name: "inclusive high", key: 5, hi: NewBound(5, true), want: true},
+		{name: "exclusive high", key: 5, hi: NewBound(5, false), want: false},
+		{name: "above high", key: 6, hi: NewBound(5, true), want: false},
+		{name: "between", key: 3, lo: NewBound(1, false), hi: NewBound(5, false), want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, rbTree.InRange(tc.key, tc.lo, tc.hi))
+		})
+	}
+}
+
+func TestRBTree_RankSelect(t *testing.T) {
+	rbTree := NewRBTree[int, int](compare())
+	_, _, ok := rbTree.Select(0)
+	assert.False(t, ok)
+	assert.Equal(t, 0, rbTree.Rank(1))
+
+	// 随机增删，定期校验子树大小，以及 Rank、Select 与中序遍历的结果一致
+	r := rand.New(rand.NewSource(1))
+	present := make(map[int]bool)
+	for i := 0; i < 2000; i++ {
+		key := r.Intn(200)
+		if r.Intn(3) == 0 {
+			_, ok = rbTree.Delete(key)
+			assert.Equal(t, present[key], ok)
+			delete(present, key)
+		} else if !present[key] {
+			assert.NoError(t, rbTree.Add(key, key*10))
+			present[key] = true
+		}
+		if i%50 != 0 {
+			continue
+		}
+		assert.True(t, isSizeConsistent[int](rbTree.root))
+		keys, _ := rbTree.KeyValues()
+		for idx, k := range keys {
+			assert.Equal(t, idx, rbTree.Rank(k))
+			key, value, ok := rbTree.Select(idx)
+			assert.True(t, ok)
+			assert.Equal(t, k, key)
+			assert.Equal(t, k*10, value)
+		}
+		// 不存在的 key 的排名
+		assert.Equal(t, len(keys), rbTree.Rank(1000))
+		assert.Equal(t, 0, rbTree.Rank(-1))
+		_, _, ok = rbTree.Select(len(keys))
+		assert.False(t, ok)
+		_, _, ok = rbTree.Select(-1)
+		assert.False(t, ok)
+	}
+}
+
+func TestRBTree_Clear(t *testing.T) {
+	rbTree := NewRBTree[int, int](compare())
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, rbTree.Add(i, i))
+	}
+	rbTree.Clear()
+	assert.Equal(t, 0, rbTree.Size())
+	_, err := rbTree.Find(1)
+	assert.Equal(t, ErrRBTreeNotExistRBNode, err)
+	assert.NoError(t, rbTree.Add(1, 1))
+	keys, _ := rbTree.KeyValues()
+	assert.Equal(t, []int{1}, keys)
+}
+
 func TestRBTree_addNode(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -971,6 +1216,7 @@ func TestNewRBNode(t *testing.T) {
 				right:  nil,
 				parent: nil,
 				color:  Red,
+				size:   1,
 			},
 		},
 	}
@@ -1428,6 +1674,22 @@ func IsRedBlackTree[K any, V any](root *rbNode[K, V]) bool {
 	return nodeCheck[K](root, count, num)
 }
 
+// isSizeConsistent 检测每个节点的子树大小是否正确
+func isSizeConsistent[K any, V any](root *rbNode[K, V]) bool {
+	var count func(n *rbNode[K, V]) (int, bool)
+	count = func(n *rbNode[K, V]) (int, bool) {
+		if n == nil {
+			return 0, true
+		}
+		left, leftOk := count(n.left)
+		right, rightOk := count(n.right)
+		size := left + right + 1
+		return size, leftOk && rightOk && n.size == size
+	}
+	_, ok := count(root)
+	return ok
+}
+
 // nodeCheck 节点检测
 // 1.是否有连续的红色节点
 // 2.每条路径的黑色节点是否一致
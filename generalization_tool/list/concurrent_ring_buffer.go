@@ -0,0 +1,63 @@
+package list
+
+import "sync"
+
+// ConcurrentRingBuffer 线程安全的 RingBuffer
+type ConcurrentRingBuffer[T any] struct {
+	*RingBuffer[T]
+	lock sync.RWMutex
+}
+
+func NewConcurrentRingBuffer[T any](capacity int) *ConcurrentRingBuffer[T] {
+	return &ConcurrentRingBuffer[T]{RingBuffer: NewRingBuffer[T](capacity)}
+}
+
+// Push 放入元素。如果缓冲区已满，那么覆盖最旧的元素，并返回被覆盖的元素和true
+func (c *ConcurrentRingBuffer[T]) Push(t T) (T, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.RingBuffer.Push(t)
+}
+
+// Get 返回下标为 index 的元素，0 为最旧的元素，Len()-1 为最新的元素
+func (c *ConcurrentRingBuffer[T]) Get(index int) (T, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.RingBuffer.Get(index)
+}
+
+// Len 返回元素数量
+func (c *ConcurrentRingBuffer[T]) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.RingBuffer.Len()
+}
+
+// IsFull 缓冲区是否已满，已满之后再 Push 会覆盖最旧的元素
+func (c *ConcurrentRingBuffer[T]) IsFull() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.RingBuffer.IsFull()
+}
+
+// Clear 清空所有元素，容量不变
+func (c *ConcurrentRingBuffer[T]) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.RingBuffer.Clear()
+}
+
+// Range 从最旧到最新遍历所有元素，遍历期间持有读锁，fn 中不能再调用写方法
+func (c *ConcurrentRingBuffer[T]) Range(fn func(index int, t T) error) error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.RingBuffer.Range(fn)
+}
+
+// AsSlice 按从最旧到最新的顺序转化为一个切片，没有元素的情况下不允许返回nil，
+// 必须返回一个len和cap为0的切片；每次调用都必须都必须返回一个新切片
+func (c *ConcurrentRingBuffer[T]) AsSlice() []T {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.RingBuffer.AsSlice()
+}
@@ -0,0 +1,248 @@
+package list
+
+import (
+	"errors"
+	"generalization_tool/internal/errs"
+	"generalization_tool/internal/slice"
+)
+
+var (
+	_ List[any] = &Deque[any]{}
+)
+
+// ErrEmptyDeque 双端队列为空
+var ErrEmptyDeque = errors.New("generalization_tool: 双端队列为空")
+
+// Deque 基于可扩容环形数组实现的双端队列，两端的插入和删除都是 O(1)
+// 下标 i 的元素存放在 buf[(head+i)%len(buf)] 的位置
+type Deque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// NewDeque 创建一个初始容量为 cap 的双端队列，cap 小于0时按0处理
+func NewDeque[T any](cap int) *Deque[T] {
+	if cap < 0 {
+		cap = 0
+	}
+	return &Deque[T]{buf: make([]T, cap)}
+}
+
+func NewDequeOf[T any](values []T) *Deque[T] {
+	buf := make([]T, len(values))
+	copy(buf, values)
+	return &Deque[T]{buf: buf, size: len(values)}
+}
+
+// PushFront 在队首添加元素
+func (d *Deque[T]) PushFront(t T) {
+	d.grow()
+	d.head = d.index(-1)
+	d.buf[d.head] = t
+	d.size++
+}
+
+// PushBack 在队尾添加元素
+func (d *Deque[T]) PushBack(t T) {
+	d.grow()
+	d.buf[d.index(d.size)] = t
+	d.size++
+}
+
+// PopFront 删除并返回队首元素
+func (d *Deque[T]) PopFront() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, ErrEmptyDeque
+	}
+	t := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+	return t, nil
+}
+
+// PopBack 删除并返回队尾元素
+func (d *Deque[T]) PopBack() (T, error) {
+	var zero T
+	if d.size == 0 {
+		return zero, ErrEmptyDeque
+	}
+	tail := d.index(d.size - 1)
+	t := d.buf[tail]
+	d.buf[tail] = zero
+	d.size--
+	d.shrink()
+	return t, nil
+}
+
+// Front 返回队首元素
+func (d *Deque[T]) Front() (T, error) {
+	if d.size == 0 {
+		var zero T
+		return zero, ErrEmptyDeque
+	}
+	return d.buf[d.head], nil
+}
+
+// Back 返回队尾元素
+func (d *Deque[T]) Back() (T, error) {
+	if d.size == 0 {
+		var zero T
+		return zero, ErrEmptyDeque
+	}
+	return d.buf[d.index(d.size-1)], nil
+}
+
+// Get 返回对应的下标元素
+func (d *Deque[T]) Get(index int) (T, error) {
+	if index < 0 || index >= d.size {
+		var zero T
+		return zero, errs.NewErrIndexOutOfRange(d.size, index)
+	}
+	return d.buf[d.index(index)], nil
+}
+
+// Append 在末尾追加元素
+func (d *Deque[T]) Append(values ...T) error {
+	for _, v := range values {
+		d.PushBack(v)
+	}
+	return nil
+}
+
+// Add 在指定位置添加新元素，会移动离 index 较近一端的元素
+func (d *Deque[T]) Add(index int, t T) error {
+	if index < 0 || index > d.size {
+		return errs.NewErrIndexOutOfRange(d.size, index)
+	}
+	d.grow()
+	if index < d.size/2 {
+		// 前半部分整体前移一位
+		d.head = d.index(-1)
+		for i := 0; i < index; i++ {
+			d.buf[d.index(i)] = d.buf[d.index(i+1)]
+		}
+	} else {
+		// 后半部分整体后移一位
+		for i := d.size; i > index; i-- {
+			d.buf[d.index(i)] = d.buf[d.index(i-1)]
+		}
+	}
+	d.buf[d.index(index)] = t
+	d.size++
+	return nil
+}
+
+// Set 更新 index 位置的值
+func (d *Deque[T]) Set(index int, t T) error {
+	if index < 0 || index >= d.size {
+		return errs.NewErrIndexOutOfRange(d.size, index)
+	}
+	d.buf[d.index(index)] = t
+	return nil
+}
+
+// Delete 删除目标元素的位置，并返回该位置的值，会移动离 index 较近一端的元素
+// 必要的时候会引起缩容，缩容规则与 ArrayList 一致
+func (d *Deque[T]) Delete(index int) (T, error) {
+	var zero T
+	if index < 0 || index >= d.size {
+		return zero, errs.NewErrIndexOutOfRange(d.size, index)
+	}
+	t := d.buf[d.index(index)]
+	if index < d.size/2 {
+		for i := index; i > 0; i-- {
+			d.buf[d.index(i)] = d.buf[d.index(i-1)]
+		}
+		d.buf[d.head] = zero
+		d.head = d.index(1)
+	} else {
+		for i := index; i < d.size-1; i++ {
+			d.buf[d.index(i)] = d.buf[d.index(i+1)]
+		}
+		d.buf[d.index(d.size-1)] = zero
+	}
+	d.size--
+	d.shrink()
+	return t, nil
+}
+
+// Len 返回长度
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// Cap 返回容量
+func (d *Deque[T]) Cap() int {
+	return len(d.buf)
+}
+
+// Range 从队首到队尾遍历所有元素
+func (d *Deque[T]) Range(fn func(index int, t T) error) error {
+	for i := 0; i < d.size; i++ {
+		if err := fn(i, d.buf[d.index(i)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsSlice 将 Deque 转化为一个切片，没有元素的情况下不允许返回nil，
+// 必须返回一个len和cap为0的切片；每次调用都必须都必须返回一个新切片
+func (d *Deque[T]) AsSlice() []T {
+	res := make([]T, d.size)
+	d.copyTo(res)
+	return res
+}
+
+// index 将逻辑下标转化为 buf 中的物理下标，i 可以为-1
+func (d *Deque[T]) index(i int) int {
+	n := len(d.buf)
+	return ((d.head+i)%n + n) % n
+}
+
+// grow 容量已满时扩容为原来的两倍，最小为 8
+func (d *Deque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	newCap := 2 * len(d.buf)
+	if newCap < 8 {
+		newCap = 8
+	}
+	d.resize(newCap)
+}
+
+// shrink 缩容规则：
+// - 如果容量 > 2048，并且长度小于容量一半，那么就会缩容为原本的 5/8
+// - 如果容量 (64, 2048]，如果长度是容量的 1/4，那么就会缩容为原本的一半
+// - 如果此时容量 <= 64，那么我们将不会执行缩容。在容量很小的情况下，浪费的内存很少，所以没必要消耗 CPU去执行缩容
+func (d *Deque[T]) shrink() {
+	if newCap, change := slice.CalculateShrinkCap(len(d.buf), d.size); change {
+		d.resize(newCap)
+	}
+}
+
+// resize 将元素按顺序搬迁到容量为 newCap 的新数组中，head 重置为0
+func (d *Deque[T]) resize(newCap int) {
+	buf := make([]T, newCap)
+	d.copyTo(buf)
+	d.buf = buf
+	d.head = 0
+}
+
+// copyTo 按照从队首到队尾的顺序将元素拷贝到 dst 中，dst 的长度不能小于 size
+func (d *Deque[T]) copyTo(dst []T) {
+	if d.size == 0 {
+		return
+	}
+	if end := d.head + d.size; end <= len(d.buf) {
+		copy(dst, d.buf[d.head:end])
+		return
+	}
+	n := copy(dst, d.buf[d.head:])
+	copy(dst[n:], d.buf[:d.size-n])
+}
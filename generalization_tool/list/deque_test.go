@@ -0,0 +1,296 @@
+package list
+
+import (
+	"errors"
+	"generalization_tool/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewDeque(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cap     int
+		wantCap int
+	}{
+		{name: "negative", cap: -1, wantCap: 0},
+		{name: "zero", cap: 0, wantCap: 0},
+		{name: "positive", cap: 4, wantCap: 4},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDeque[int](tc.cap)
+			assert.Equal(t, tc.wantCap, d.Cap())
+			d.PushBack(1)
+			assert.Equal(t, 1, d.Len())
+		})
+	}
+}
+
+func TestDeque_PushPop(t *testing.T) {
+	d := NewDeque[int](0)
+	_, err := d.PopFront()
+	assert.Equal(t, ErrEmptyDeque, err)
+	_, err = d.PopBack()
+	assert.Equal(t, ErrEmptyDeque, err)
+	_, err = d.Front()
+	assert.Equal(t, ErrEmptyDeque, err)
+	_, err = d.Back()
+	assert.Equal(t, ErrEmptyDeque, err)
+
+	// 交替在两端插入，触发环绕和扩容
+	for i := 1; i <= 10; i++ {
+		d.PushBack(i)
+		d.PushFront(-i)
+	}
+	assert.Equal(t, 20, d.Len())
+	assert.Equal(t, []int{-10, -9, -8, -7, -6, -5, -4, -3, -2, -1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, d.AsSlice())
+
+	front, err := d.Front()
+	assert.NoError(t, err)
+	assert.Equal(t, -10, front)
+	back, err := d.Back()
+	assert.NoError(t, err)
+	assert.Equal(t, 10, back)
+
+	for i := 10; i >= 1; i-- {
+		val, err := d.PopFront()
+		assert.NoError(t, err)
+		assert.Equal(t, -i, val)
+		val, err = d.PopBack()
+		assert.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+	assert.Equal(t, 0, d.Len())
+	assert.Equal(t, []int{}, d.AsSlice())
+}
+
+func TestDeque_Add(t *testing.T) {
+	testCases := []struct {
+		name    string
+		deque   func() *Deque[int]
+		index   int
+		newVal  int
+		wantRes []int
+		wantErr error
+	}{
+		{
+			name:    "add to empty",
+			deque:   func() *Deque[int] { return NewDeque[int](0) },
+			index:   0,
+			newVal:  1,
+			wantRes: []int{1},
+		},
+		{
+			name:    "add num to index left",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3}) },
+			index:   0,
+			newVal:  0,
+			wantRes: []int{0, 1, 2, 3},
+		},
+		{
+			name:    "add num to index right",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3}) },
+			index:   3,
+			newVal:  4,
+			wantRes: []int{1, 2, 3, 4},
+		},
+		{
+			name:    "add num to front half",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3, 4, 5, 6}) },
+			index:   2,
+			newVal:  10,
+			wantRes: []int{1, 2, 10, 3, 4, 5, 6},
+		},
+		{
+			name:    "add num to back half",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3, 4, 5, 6}) },
+			index:   4,
+			newVal:  10,
+			wantRes: []int{1, 2, 3, 4, 10, 5, 6},
+		},
+		{
+			name: "add num across wrap",
+			deque: func() *Deque[int] {
+				d := NewDeque[int](8)
+				for i := 4; i <= 6; i++ {
+					d.PushBack(i)
+				}
+				for i := 3; i >= 1; i-- {
+					d.PushFront(i)
+				}
+				return d
+			},
+			index:   1,
+			newVal:  10,
+			wantRes: []int{1, 10, 2, 3, 4, 5, 6},
+		},
+		{
+			name:    "add num to index -1",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3}) },
+			index:   -1,
+			newVal:  4,
+			wantErr: errs.NewErrIndexOutOfRange(3, -1),
+		},
+		{
+			name:    "add num to index OutOfRange",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3}) },
+			index:   4,
+			newVal:  4,
+			wantErr: errs.NewErrIndexOutOfRange(3, 4),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := tc.deque()
+			err := d.Add(tc.index, tc.newVal)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantRes, d.AsSlice())
+		})
+	}
+}
+
+func TestDeque_Delete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		deque   func() *Deque[int]
+		index   int
+		wantVal int
+		wantRes []int
+		wantErr error
+	}{
+		{
+			name:    "delete front half",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3, 4, 5, 6}) },
+			index:   1,
+			wantVal: 2,
+			wantRes: []int{1, 3, 4, 5, 6},
+		},
+		{
+			name:    "delete back half",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3, 4, 5, 6}) },
+			index:   4,
+			wantVal: 5,
+			wantRes: []int{1, 2, 3, 4, 6},
+		},
+		{
+			name: "delete across wrap",
+			deque: func() *Deque[int] {
+				d := NewDeque[int](8)
+				for i := 4; i <= 6; i++ {
+					d.PushBack(i)
+				}
+				for i := 3; i >= 1; i-- {
+					d.PushFront(i)
+				}
+				return d
+			},
+			index:   3,
+			wantVal: 4,
+			wantRes: []int{1, 2, 3, 5, 6},
+		},
+		{
+			name:    "index out of range",
+			deque:   func() *Deque[int] { return NewDequeOf[int]([]int{1, 2, 3}) },
+			index:   3,
+			wantErr: errs.NewErrIndexOutOfRange(3, 3),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := tc.deque()
+			val, err := d.Delete(tc.index)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantRes, d.AsSlice())
+		})
+	}
+}
+
+func TestDeque_Shrink(t *testing.T) {
+	testCases := []struct {
+		name    string
+		size    int
+		remain  int
+		wantCap int
+	}{
+		{
+			name:    "cap <= 64",
+			size:    64,
+			remain:  1,
+			wantCap: 64,
+		},
+		{
+			name:    "cap (64, 2048]",
+			size:    128,
+			remain:  32,
+			wantCap: 64,
+		},
+		{
+			name:    "cap > 2048",
+			size:    4096,
+			remain:  2048,
+			wantCap: 2560,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDeque[int](tc.size)
+			for i := 0; i < tc.size; i++ {
+				d.PushBack(i)
+			}
+			for d.Len() > tc.remain {
+				_, err := d.PopFront()
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantCap, d.Cap())
+			front, err := d.Front()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.size-tc.remain, front)
+		})
+	}
+}
+
+func TestDeque_GetSet(t *testing.T) {
+	d := NewDequeOf[int]([]int{1, 2, 3})
+	d.PushFront(0)
+	val, err := d.Get(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, val)
+	_, err = d.Get(4)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(4, 4), err)
+
+	assert.NoError(t, d.Set(3, 30))
+	assert.Equal(t, errs.NewErrIndexOutOfRange(4, 4), d.Set(4, 1))
+	assert.Equal(t, []int{0, 1, 2, 30}, d.AsSlice())
+}
+
+func TestDeque_Range(t *testing.T) {
+	d := NewDeque[int](4)
+	_ = d.Append(2, 3)
+	d.PushFront(1)
+	res := make([]int, 0, d.Len())
+	err := d.Range(func(index int, t int) error {
+		res = append(res, t)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, res)
+
+	wantErr := errors.New("stop")
+	err = d.Range(func(index int, t int) error {
+		if index == 1 {
+			return wantErr
+		}
+		return nil
+	})
+	assert.Equal(t, wantErr, err)
+}
@@ -0,0 +1,93 @@
+package list
+
+import "generalization_tool/internal/errs"
+
+// RingBuffer 固定容量的环形缓冲区，已满时 Push 会覆盖最旧的元素
+// 适用于保留最近 N 条记录的场景；非线程安全，并发场景请使用 ConcurrentRingBuffer
+type RingBuffer[T any] struct {
+	buf []T
+	// head 最旧元素的下标
+	head int
+	size int
+}
+
+// NewRingBuffer 创建一个容量为 capacity 的环形缓冲区，capacity <= 0 时不会保留任何元素
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity)}
+}
+
+// Push 放入元素。如果缓冲区已满，那么覆盖最旧的元素，并返回被覆盖的元素和true
+func (r *RingBuffer[T]) Push(t T) (T, bool) {
+	var zero T
+	if len(r.buf) == 0 {
+		return zero, false
+	}
+	if r.size < len(r.buf) {
+		r.buf[(r.head+r.size)%len(r.buf)] = t
+		r.size++
+		return zero, false
+	}
+	old := r.buf[r.head]
+	r.buf[r.head] = t
+	r.head = (r.head + 1) % len(r.buf)
+	return old, true
+}
+
+// Get 返回下标为 index 的元素，0 为最旧的元素，Len()-1 为最新的元素
+func (r *RingBuffer[T]) Get(index int) (T, error) {
+	if index < 0 || index >= r.size {
+		var zero T
+		return zero, errs.NewErrIndexOutOfRange(r.size, index)
+	}
+	return r.buf[(r.head+index)%len(r.buf)], nil
+}
+
+// Len 返回元素数量
+func (r *RingBuffer[T]) Len() int {
+	return r.size
+}
+
+// Cap 返回容量
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.buf)
+}
+
+// IsFull 缓冲区是否已满，已满之后再 Push 会覆盖最旧的元素
+func (r *RingBuffer[T]) IsFull() bool {
+	return r.size == len(r.buf)
+}
+
+// Clear 清空所有元素，容量不变
+func (r *RingBuffer[T]) Clear() {
+	var zero T
+	for i := range r.buf {
+		r.buf[i] = zero
+	}
+	r.head, r.size = 0, 0
+}
+
+// Range 从最旧到最新遍历所有元素
+func (r *RingBuffer[T]) Range(fn func(index int, t T) error) error {
+	for i := 0; i < r.size; i++ {
+		if err := fn(i, r.buf[(r.head+i)%len(r.buf)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AsSlice 按从最旧到最新的顺序转化为一个切片，没有元素的情况下不允许返回nil，
+// 必须返回一个len和cap为0的切片；每次调用都必须都必须返回一个新切片
+func (r *RingBuffer[T]) AsSlice() []T {
+	res := make([]T, r.size)
+	if end := r.head + r.size; end <= len(r.buf) {
+		copy(res, r.buf[r.head:end])
+		return res
+	}
+	n := copy(res, r.buf[r.head:])
+	copy(res[n:], r.buf[:r.size-n])
+	return res
+}
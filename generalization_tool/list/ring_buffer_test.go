@@ -0,0 +1,164 @@
+package list
+
+import (
+	"errors"
+	"generalization_tool/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestRingBuffer_Push(t *testing.T) {
+	testCases := []struct {
+		name        string
+		capacity    int
+		values      []int
+		wantRes     []int
+		wantEvicted []int
+	}{
+		{
+			name:        "zero capacity",
+			capacity:    0,
+			values:      []int{1, 2},
+			wantRes:     []int{},
+			wantEvicted: []int{},
+		},
+		{
+			name:        "not full",
+			capacity:    3,
+			values:      []int{1, 2},
+			wantRes:     []int{1, 2},
+			wantEvicted: []int{},
+		},
+		{
+			name:        "full",
+			capacity:    3,
+			values:      []int{1, 2, 3},
+			wantRes:     []int{1, 2, 3},
+			wantEvicted: []int{},
+		},
+		{
+			name:        "overwrite",
+			capacity:    3,
+			values:      []int{1, 2, 3, 4, 5},
+			wantRes:     []int{3, 4, 5},
+			wantEvicted: []int{1, 2},
+		},
+		{
+			name:        "overwrite many rounds",
+			capacity:    2,
+			values:      []int{1, 2, 3, 4, 5, 6, 7},
+			wantRes:     []int{6, 7},
+			wantEvicted: []int{1, 2, 3, 4, 5},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRingBuffer[int](tc.capacity)
+			evicted := make([]int, 0)
+			for _, v := range tc.values {
+				if old, ok := r.Push(v); ok {
+					evicted = append(evicted, old)
+				}
+			}
+			assert.Equal(t, tc.wantRes, r.AsSlice())
+			assert.Equal(t, tc.wantEvicted, evicted)
+			assert.Equal(t, len(tc.wantRes), r.Len())
+			assert.Equal(t, tc.capacity, r.Cap())
+			assert.Equal(t, r.Len() == r.Cap(), r.IsFull())
+		})
+	}
+}
+
+func TestRingBuffer_Get(t *testing.T) {
+	r := NewRingBuffer[int](3)
+	for i := 1; i <= 4; i++ {
+		r.Push(i)
+	}
+	for i, want := range []int{2, 3, 4} {
+		val, err := r.Get(i)
+		assert.NoError(t, err)
+		assert.Equal(t, want, val)
+	}
+	_, err := r.Get(3)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, 3), err)
+	_, err = r.Get(-1)
+	assert.Equal(t, errs.NewErrIndexOutOfRange(3, -1), err)
+}
+
+func TestRingBuffer_Range(t *testing.T) {
+	r := NewRingBuffer[int](3)
+	for i := 1; i <= 5; i++ {
+		r.Push(i)
+	}
+	res := make([]int, 0, r.Len())
+	err := r.Range(func(index int, t int) error {
+		res = append(res, t)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4, 5}, res)
+
+	wantErr := errors.New("stop")
+	res = res[:0]
+	err = r.Range(func(index int, t int) error {
+		if index == 1 {
+			return wantErr
+		}
+		res = append(res, t)
+		return nil
+	})
+	assert.Equal(t, wantErr, err)
+	assert.Equal(t, []int{3}, res)
+}
+
+func TestRingBuffer_Clear(t *testing.T) {
+	r := NewRingBuffer[int](3)
+	for i := 1; i <= 5; i++ {
+		r.Push(i)
+	}
+	r.Clear()
+	assert.Equal(t, 0, r.Len())
+	assert.Equal(t, 3, r.Cap())
+	assert.Equal(t, []int{}, r.AsSlice())
+	r.Push(6)
+	assert.Equal(t, []int{6}, r.AsSlice())
+}
+
+func TestRingBuffer_AsSlice(t *testing.T) {
+	r := NewRingBuffer[int](3)
+	r.Push(1)
+	res := r.AsSlice()
+	res[0] = 10
+	// 每次返回的都是新切片，修改不会影响缓冲区
+	assert.Equal(t, []int{1}, r.AsSlice())
+}
+
+func TestConcurrentRingBuffer(t *testing.T) {
+	r := NewConcurrentRingBuffer[int](100)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(base int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Push(base + j)
+			}
+		}(i * 100)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = r.AsSlice()
+				_ = r.Range(func(index int, t int) error {
+					return nil
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, r.Len())
+	assert.True(t, r.IsFull())
+	assert.Equal(t, 100, r.Cap())
+	r.Clear()
+	assert.Equal(t, []int{}, r.AsSlice())
+}
@@ -0,0 +1,262 @@
+package mapx
+
+import "generalization_tool"
+
+// ARC 自适应替换缓存（Adaptive Replacement Cache），同时兼顾访问的时间局部性和频率
+// - t1 只被访问过一次的元素，t2 至少被访问过两次的元素，两者之和不超过容量
+// - b1、b2 分别是最近从 t1、t2 淘汰的 key（幽灵节点，不保存值）
+// - p 是 t1 的目标大小：命中 b1 说明 t1 太小，增大 p；命中 b2 说明 t2 太小，减小 p
+// 相比 LRU，一次性的顺序扫描只会冲刷 t1，不会淘汰 t2 中的热点数据
+type ARC[K any, V any] struct {
+	cacheStats
+	m        Map[K, *cacheEntry[K, V]]
+	t1       *cacheList[K, V]
+	t2       *cacheList[K, V]
+	b1       *cacheList[K, V]
+	b2       *cacheList[K, V]
+	p        int
+	capacity int
+	onEvict  func(key K, value V)
+}
+
+// NewHashMapARC 创建一个基于 HashMap 的 ARC，capacity 必须大于0
+func NewHashMapARC[K Hashable, V any](capacity int) (*ARC[K, V], error) {
+	if capacity <= 0 {
+		return nil, errCacheInvalidCapacity
+	}
+	return newARC[K, V](NewHashMap[K, *cacheEntry[K, V]](2*capacity), capacity), nil
+}
+
+// NewTreeMapARC 创建一个基于 TreeMap 的 ARC，capacity 必须大于0，comparator不能为nil
+func NewTreeMapARC[K any, V any](capacity int, comparator generalization_tool.Comparator[K]) (*ARC[K, V], error) {
+	if capacity <= 0 {
+		return nil, errCacheInvalidCapacity
+	}
+	treeMap, err := NewTreeMap[K, *cacheEntry[K, V]](comparator)
+	if err != nil {
+		return nil, err
+	}
+	return newARC[K, V](treeMap, capacity), nil
+}
+
+func newARC[K any, V any](m Map[K, *cacheEntry[K, V]], capacity int) *ARC[K, V] {
+	return &ARC[K, V]{
+		m:        m,
+		t1:       newCacheList[K, V](),
+		t2:       newCacheList[K, V](),
+		b1:       newCacheList[K, V](),
+		b2:       newCacheList[K, V](),
+		capacity: capacity,
+	}
+}
+
+// SetOnEvict 设置元素因为容量不足被淘汰时的回调，主动 Delete 不会触发回调
+func (a *ARC[K, V]) SetOnEvict(fn func(key K, value V)) {
+	a.onEvict = fn
+}
+
+// Get 返回 key 对应的值，命中的元素会被移动到 t2；会被计入命中统计
+func (a *ARC[K, V]) Get(key K) (V, bool) {
+	e, ok := a.m.Get(key)
+	hit := ok && a.isCached(e)
+	a.record(hit)
+	if !hit {
+		var zero V
+		return zero, false
+	}
+	a.promote(e)
+	return e.value, true
+}
+
+// Put 放入键值对
+// - key 在 t1 或 t2 中：更新值并移动到 t2
+// - key 在 b1 或 b2 中：调整 p，腾出空间后放入 t2
+// - 新的 key：腾出空间后放入 t1
+func (a *ARC[K, V]) Put(key K, value V) error {
+	e, ok := a.m.Get(key)
+	if !ok {
+		return a.putNew(key, value)
+	}
+	switch e.list {
+	case a.t1, a.t2:
+		e.value = value
+		a.promote(e)
+	case a.b1:
+		// 命中 b1，说明 t1 太小
+		a.p = minInt(a.capacity, a.p+maxInt(1, a.b2.length/a.b1.length))
+		a.b1.remove(e)
+		a.replace(false)
+		e.value = value
+		a.t2.pushBack(e)
+	case a.b2:
+		// 命中 b2，说明 t2 太小
+		a.p = maxInt(0, a.p-maxInt(1, a.b1.length/a.b2.length))
+		a.b2.remove(e)
+		a.replace(true)
+		e.value = value
+		a.t2.pushBack(e)
+	}
+	return nil
+}
+
+func (a *ARC[K, V]) putNew(key K, value V) error {
+	e := &cacheEntry[K, V]{key: key, value: value}
+	if err := a.m.Put(key, e); err != nil {
+		return err
+	}
+	a.replace(false)
+	// 限制幽灵节点的数量，保证 b1 + b2 不超过容量
+	if a.b1.length > a.capacity-a.p {
+		a.dropGhost(a.b1)
+	}
+	if a.b2.length > a.p {
+		a.dropGhost(a.b2)
+	}
+	a.t1.pushBack(e)
+	return nil
+}
+
+// Peek 返回 key 对应的值，不会改变元素所在的位置
+func (a *ARC[K, V]) Peek(key K) (V, bool) {
+	if e, ok := a.m.Get(key); ok && a.isCached(e) {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains 判断 key 是否在缓存中，幽灵节点不算在内
+func (a *ARC[K, V]) Contains(key K) bool {
+	e, ok := a.m.Get(key)
+	return ok && a.isCached(e)
+}
+
+// Delete 删除 key，不会触发淘汰回调
+func (a *ARC[K, V]) Delete(key K) (V, bool) {
+	e, ok := a.m.Delete(key)
+	var zero V
+	if !ok {
+		return zero, false
+	}
+	cached := a.isCached(e)
+	e.list.remove(e)
+	if !cached {
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Len 返回缓存中的元素数量，幽灵节点不算在内
+func (a *ARC[K, V]) Len() int {
+	return a.t1.length + a.t2.length
+}
+
+// Cap 返回容量
+func (a *ARC[K, V]) Cap() int {
+	return a.capacity
+}
+
+// Keys 返回缓存中所有的键，先返回 t1 再返回 t2，各自按照从久到新的顺序
+func (a *ARC[K, V]) Keys() []K {
+	keys := make([]K, 0, a.Len())
+	keys = a.t1.appendKeys(keys)
+	return a.t2.appendKeys(keys)
+}
+
+// Values 返回缓存中所有的值，顺序与 Keys 一致
+func (a *ARC[K, V]) Values() []V {
+	values := make([]V, 0, a.Len())
+	a.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Range 按照与 Keys 相同的顺序遍历，幽灵节点不算在内，不会改变元素所在的链表
+func (a *ARC[K, V]) Range(fn func(key K, value V) bool) {
+	if a.t1.rangeEntries(fn) {
+		a.t2.rangeEntries(fn)
+	}
+}
+
+// Clear 删除所有元素和幽灵节点，不会触发淘汰回调，也不会清空命中统计
+func (a *ARC[K, V]) Clear() {
+	a.m.Clear()
+	a.t1.clear()
+	a.t2.clear()
+	a.b1.clear()
+	a.b2.clear()
+	a.p = 0
+}
+
+func (a *ARC[K, V]) isCached(e *cacheEntry[K, V]) bool {
+	return e.list == a.t1 || e.list == a.t2
+}
+
+// promote 将 t1 或 t2 中的元素移动到 t2 的尾部
+func (a *ARC[K, V]) promote(e *cacheEntry[K, V]) {
+	if e.list == a.t2 {
+		a.t2.moveToBack(e)
+		return
+	}
+	a.t1.remove(e)
+	a.t2.pushBack(e)
+}
+
+// replace 缓存已满时，根据 p 从 t1 或 t2 中淘汰一个元素到对应的幽灵链表
+func (a *ARC[K, V]) replace(hitB2 bool) {
+	if a.Len() < a.capacity {
+		return
+	}
+	t1Len := a.t1.length
+	if t1Len > 0 && (t1Len > a.p || (t1Len == a.p && hitB2)) {
+		a.demote(a.t1, a.b1)
+		return
+	}
+	if a.t2.length > 0 {
+		a.demote(a.t2, a.b2)
+		return
+	}
+	a.demote(a.t1, a.b1)
+}
+
+// demote 将 from 中最久的元素淘汰到幽灵链表 to 中
+func (a *ARC[K, V]) demote(from *cacheList[K, V], to *cacheList[K, V]) {
+	e := from.front()
+	if e == nil {
+		return
+	}
+	from.remove(e)
+	key, value := e.key, e.value
+	var zero V
+	e.value = zero
+	to.pushBack(e)
+	if a.onEvict != nil {
+		a.onEvict(key, value)
+	}
+}
+
+// dropGhost 彻底删除幽灵链表中最久的 key
+func (a *ARC[K, V]) dropGhost(ghost *cacheList[K, V]) {
+	e := ghost.front()
+	if e == nil {
+		return
+	}
+	ghost.remove(e)
+	a.m.Delete(e.key)
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
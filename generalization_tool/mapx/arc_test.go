@@ -0,0 +1,98 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewARC(t *testing.T) {
+	_, err := NewHashMapARC[testData, int](0)
+	assert.Equal(t, errCacheInvalidCapacity, err)
+	_, err = NewTreeMapARC[int, int](0, compare())
+	assert.Equal(t, errCacheInvalidCapacity, err)
+	_, err = NewTreeMapARC[int, int](1, nil)
+	assert.Equal(t, errTreeMapComparatorIsNull, err)
+
+	arc, err := NewHashMapARC[testData, int](2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, arc.Cap())
+	assert.Equal(t, 0, arc.Len())
+	assert.Equal(t, []testData{}, arc.Keys())
+}
+
+func TestARC_Put(t *testing.T) {
+	arc, err := NewTreeMapARC[int, int](2, compare())
+	assert.NoError(t, err)
+	evicted := make([]int, 0)
+	arc.SetOnEvict(func(key int, value int) {
+		assert.Equal(t, key*10, value)
+		evicted = append(evicted, key)
+	})
+
+	assert.NoError(t, arc.Put(1, 10))
+	assert.NoError(t, arc.Put(2, 20))
+	assert.Equal(t, []int{1, 2}, arc.Keys())
+
+	// 1 被访问两次，进入 t2
+	_, ok := arc.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 1}, arc.Keys())
+
+	// 新的 key 淘汰 t1 中的 2，2 成为 b1 中的幽灵节点
+	assert.NoError(t, arc.Put(3, 30))
+	assert.Equal(t, []int{3, 1}, arc.Keys())
+	assert.Equal(t, []int{2}, evicted)
+	assert.False(t, arc.Contains(2))
+	_, ok = arc.Peek(2)
+	assert.False(t, ok)
+
+	// 命中 b1，p 增大到1，此时 t1 没有超过目标大小，所以淘汰 t2 中的 1，2 直接进入 t2
+	assert.NoError(t, arc.Put(2, 20))
+	assert.Equal(t, 1, arc.p)
+	assert.True(t, arc.Contains(2))
+	assert.Equal(t, 2, arc.Len())
+	assert.Equal(t, []int{2, 1}, evicted)
+	assert.Equal(t, []int{3, 2}, arc.Keys())
+
+	// 更新已有的值，3 进入 t2
+	assert.NoError(t, arc.Put(3, 300))
+	val, ok := arc.Peek(3)
+	assert.True(t, ok)
+	assert.Equal(t, 300, val)
+	assert.Equal(t, []int{2, 3}, arc.Keys())
+}
+
+func TestARC_Delete(t *testing.T) {
+	arc, err := NewHashMapARC[testData, int](1)
+	assert.NoError(t, err)
+	assert.NoError(t, arc.Put(newTestData(1), 1))
+	assert.NoError(t, arc.Put(newTestData(2), 2))
+
+	// 幽灵节点不算在缓存中
+	_, ok := arc.Delete(newTestData(1))
+	assert.False(t, ok)
+	val, ok := arc.Delete(newTestData(2))
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+	assert.Equal(t, 0, arc.Len())
+	_, ok = arc.Get(newTestData(2))
+	assert.False(t, ok)
+	assert.Equal(t, CacheStats{Misses: 1}, arc.Stats())
+}
+
+// TestARC_ScanResistant 一次性的顺序扫描不会淘汰 ARC 中的热点数据
+func TestARC_ScanResistant(t *testing.T) {
+	arc, err := NewTreeMapARC[int, int](10, compare())
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, arc.Put(i, i))
+		_, _ = arc.Get(i)
+	}
+	for i := 100; i < 200; i++ {
+		assert.NoError(t, arc.Put(i, i))
+	}
+	for i := 0; i < 5; i++ {
+		assert.True(t, arc.Contains(i))
+	}
+	assert.Equal(t, 10, arc.Len())
+}
@@ -0,0 +1,644 @@
+package mapx
+
+import (
+	"errors"
+	"generalization_tool"
+	"sort"
+)
+
+var _ Map[any, any] = (*BTree[any, any])(nil)
+
+var (
+	errBTreeComparatorIsNull = errors.New("BTree：Comparator不能为nil")
+	errBTreeDegreeTooSmall   = errors.New("BTree：degree不能小于2")
+	errBTreeLengthMismatch   = errors.New("BTree：keys和values的长度不一致")
+	errBTreeKeysNotSorted    = errors.New("BTree：keys必须严格递增")
+)
+
+// BTree 基于 B 树实现的有序 map，不是并发安全的
+// 每个节点连续存放多个键值对，与 TreeMap 相比指针跳转和内存分配都少得多，适合存放大量的键。
+// degree 是 B 树的最小度数：除根节点以外，每个节点包含 degree-1 到 2*degree-1 个键值对。
+// Clone 是写时复制的，复制本身是 O(1) 的，之后两棵树在修改时才会复制各自路径上的节点
+type BTree[K any, V any] struct {
+	root    *btreeNode[K, V]
+	compare generalization_tool.Comparator[K]
+	degree  int
+	length  int
+	// cow 当前树拥有的节点都指向同一个 cow，不属于当前树的节点在修改之前需要先复制
+	cow *btreeCopyOnWrite
+}
+
+// btreeCopyOnWrite 只用来比较指针，不能是空结构体，空结构体的指针可能相等
+type btreeCopyOnWrite struct {
+	_ byte
+}
+
+type btreeItem[K any, V any] struct {
+	key   K
+	value V
+}
+
+type btreeNode[K any, V any] struct {
+	items    []btreeItem[K, V]
+	children []*btreeNode[K, V]
+	cow      *btreeCopyOnWrite
+}
+
+// removeType 删除节点时的三种情况：删除指定的 key、删除最小值、删除最大值
+type removeType int
+
+const (
+	removeItem removeType = iota
+	removeMin
+	removeMax
+)
+
+// NewBTree 创建一个空的 BTree，degree不能小于2，compare不能为nil
+func NewBTree[K any, V any](degree int, compare generalization_tool.Comparator[K]) (*BTree[K, V], error) {
+	if compare == nil {
+		return nil, errBTreeComparatorIsNull
+	}
+	if degree < 2 {
+		return nil, errBTreeDegreeTooSmall
+	}
+	return &BTree[K, V]{
+		compare: compare,
+		degree:  degree,
+		cow:     &btreeCopyOnWrite{},
+	}, nil
+}
+
+// NewBTreeWithSorted 使用严格递增的 keys 和对应的 values 批量构建 BTree，时间复杂度 O(n)
+// 节点自底向上一次性填满，比逐个 Put 快得多
+func NewBTreeWithSorted[K any, V any](degree int, compare generalization_tool.Comparator[K], keys []K, values []V) (*BTree[K, V], error) {
+	b, err := NewBTree[K, V](degree, compare)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != len(values) {
+		return nil, errBTreeLengthMismatch
+	}
+	for i := 1; i < len(keys); i++ {
+		if compare(keys[i-1], keys[i]) >= 0 {
+			return nil, errBTreeKeysNotSorted
+		}
+	}
+	if len(keys) == 0 {
+		return b, nil
+	}
+	items := make([]btreeItem[K, V], len(keys))
+	for i := range keys {
+		items[i] = btreeItem[K, V]{key: keys[i], value: values[i]}
+	}
+	// 找到能够容纳所有键值对的最小高度，高度为 h 的树最多容纳 (2*degree)^h - 1 个键值对
+	height, capacity := 1, b.maxItems()
+	for capacity < len(items) {
+		height++
+		capacity = (capacity+1)*2*degree - 1
+	}
+	b.root = b.build(items, height, capacity)
+	b.length = len(items)
+	return b, nil
+}
+
+// build 使用 items 构建高度为 height 的子树，capacity 是该高度的子树最多能容纳的键值对数量
+// 子节点的数量取能够放下所有键值对的最小值，再把键值对平均分给各个子节点，这样每个子节点都不会少于最小数量
+func (b *BTree[K, V]) build(items []btreeItem[K, V], height int, capacity int) *btreeNode[K, V] {
+	n := b.newNode()
+	if height == 1 {
+		n.items = append(make([]btreeItem[K, V], 0, len(items)), items...)
+		return n
+	}
+	childCapacity := (capacity+1)/(2*b.degree) - 1
+	count := (len(items) + childCapacity + 1) / (childCapacity + 1)
+	rest := len(items) - (count - 1)
+	n.items = make([]btreeItem[K, V], 0, count-1)
+	n.children = make([]*btreeNode[K, V], 0, count)
+	start := 0
+	for i := 0; i < count; i++ {
+		size := rest / count
+		if i < rest%count {
+			size++
+		}
+		n.children = append(n.children, b.build(items[start:start+size], height-1, childCapacity))
+		start += size
+		if i < count-1 {
+			n.items = append(n.items, items[start])
+			start++
+		}
+	}
+	return n
+}
+
+// Put 插入键值对，若已存在该key，value将会被替换
+func (b *BTree[K, V]) Put(key K, value V) error {
+	item := btreeItem[K, V]{key: key, value: value}
+	if b.root == nil {
+		b.root = b.newNode()
+		b.root.items = append(b.root.items, item)
+		b.length++
+		return nil
+	}
+	b.root = b.mutable(b.root)
+	if len(b.root.items) >= b.maxItems() {
+		mid, second := b.split(b.root, b.maxItems()/2)
+		oldRoot := b.root
+		b.root = b.newNode()
+		b.root.items = append(b.root.items, mid)
+		b.root.children = append(b.root.children, oldRoot, second)
+	}
+	if b.insert(b.root, item) {
+		b.length++
+	}
+	return nil
+}
+
+// Get 返回 key 对应的 value，若未找到则会返回false
+func (b *BTree[K, V]) Get(key K) (V, bool) {
+	for n := b.root; n != nil; {
+		i, found := b.find(n, key)
+		if found {
+			return n.items[i].value, true
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete 删除 key 对应的键值对，并返回被删除的值
+func (b *BTree[K, V]) Delete(key K) (V, bool) {
+	return b.deleteItem(key, removeItem)
+}
+
+// DeleteRange 删除 key 在 from 和 to 之间的键值对，fromInclusive、toInclusive 分别表示是否包含两个端点
+// 返回被删除的键值对数量
+func (b *BTree[K, V]) DeleteRange(from K, fromInclusive bool, to K, toInclusive bool) int {
+	keys := make([]K, 0)
+	b.AscendRange(from, fromInclusive, to, toInclusive, func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	for _, key := range keys {
+		b.Delete(key)
+	}
+	return len(keys)
+}
+
+// Keys 返回全部的键，按照 key 从小到大的顺序
+func (b *BTree[K, V]) Keys() []K {
+	keys := make([]K, 0, b.length)
+	b.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values 返回全部的值，按照 key 从小到大的顺序
+func (b *BTree[K, V]) Values() []V {
+	values := make([]V, 0, b.length)
+	b.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Len 返回键值对数量
+func (b *BTree[K, V]) Len() int {
+	return b.length
+}
+
+// Contains 判断 key 是否存在
+func (b *BTree[K, V]) Contains(key K) bool {
+	_, ok := b.Get(key)
+	return ok
+}
+
+// Clear 删除所有键值对，不会影响 Clone 出来的树
+func (b *BTree[K, V]) Clear() {
+	b.root = nil
+	b.length = 0
+}
+
+// Clone 返回当前树的副本，时间复杂度 O(1)
+// 两棵树共享所有节点，之后任意一棵树修改时只复制被修改的节点，两棵树之间互不影响
+func (b *BTree[K, V]) Clone() *BTree[K, V] {
+	// 两棵树都换上新的 cow，原来的节点对双方来说都不再属于自己
+	out := *b
+	b.cow = &btreeCopyOnWrite{}
+	out.cow = &btreeCopyOnWrite{}
+	return &out
+}
+
+// Range 按照 key 从小到大的顺序遍历，fn 返回 false 时停止遍历
+func (b *BTree[K, V]) Range(fn func(key K, value V) bool) {
+	if b.root != nil {
+		b.ascend(b.root, nil, false, fn)
+	}
+}
+
+// AscendFrom 从大于 from（inclusive 为 true 时大于等于）的第一个键开始按顺序遍历，fn 返回 false 时停止遍历
+func (b *BTree[K, V]) AscendFrom(from K, inclusive bool, fn func(key K, value V) bool) {
+	if b.root != nil {
+		b.ascend(b.root, &from, inclusive, fn)
+	}
+}
+
+// AscendRange 按顺序遍历 key 在 from 和 to 之间的键值对，fromInclusive、toInclusive 分别表示是否包含两个端点
+func (b *BTree[K, V]) AscendRange(from K, fromInclusive bool, to K, toInclusive bool, fn func(key K, value V) bool) {
+	b.AscendFrom(from, fromInclusive, func(key K, value V) bool {
+		cmp := b.compare(key, to)
+		if cmp > 0 || (cmp == 0 && !toInclusive) {
+			return false
+		}
+		return fn(key, value)
+	})
+}
+
+// FirstEntry 返回最小的键值对，BTree为空时返回false
+func (b *BTree[K, V]) FirstEntry() (K, V, bool) {
+	n := b.root
+	if n == nil {
+		return b.noEntry()
+	}
+	for len(n.children) > 0 {
+		n = n.children[0]
+	}
+	return n.items[0].key, n.items[0].value, true
+}
+
+// LastEntry 返回最大的键值对，BTree为空时返回false
+func (b *BTree[K, V]) LastEntry() (K, V, bool) {
+	n := b.root
+	if n == nil {
+		return b.noEntry()
+	}
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+	}
+	item := n.items[len(n.items)-1]
+	return item.key, item.value, true
+}
+
+// FloorKey 返回小于等于 key 的最大的键，不存在时返回false
+func (b *BTree[K, V]) FloorKey(key K) (K, bool) {
+	k, _, ok := b.FloorEntry(key)
+	return k, ok
+}
+
+// FloorEntry 返回小于等于 key 的最大的键值对，不存在时返回false
+func (b *BTree[K, V]) FloorEntry(key K) (K, V, bool) {
+	return b.floor(key, true)
+}
+
+// LowerKey 返回严格小于 key 的最大的键，不存在时返回false
+func (b *BTree[K, V]) LowerKey(key K) (K, bool) {
+	k, _, ok := b.LowerEntry(key)
+	return k, ok
+}
+
+// LowerEntry 返回严格小于 key 的最大的键值对，不存在时返回false
+func (b *BTree[K, V]) LowerEntry(key K) (K, V, bool) {
+	return b.floor(key, false)
+}
+
+// CeilingKey 返回大于等于 key 的最小的键，不存在时返回false
+func (b *BTree[K, V]) CeilingKey(key K) (K, bool) {
+	k, _, ok := b.CeilingEntry(key)
+	return k, ok
+}
+
+// CeilingEntry 返回大于等于 key 的最小的键值对，不存在时返回false
+func (b *BTree[K, V]) CeilingEntry(key K) (K, V, bool) {
+	return b.ceiling(key, true)
+}
+
+// HigherKey 返回严格大于 key 的最小的键，不存在时返回false
+func (b *BTree[K, V]) HigherKey(key K) (K, bool) {
+	k, _, ok := b.HigherEntry(key)
+	return k, ok
+}
+
+// HigherEntry 返回严格大于 key 的最小的键值对，不存在时返回false
+func (b *BTree[K, V]) HigherEntry(key K) (K, V, bool) {
+	return b.ceiling(key, false)
+}
+
+// PollFirst 删除并返回最小的键值对，BTree为空时返回false
+func (b *BTree[K, V]) PollFirst() (K, V, bool) {
+	key, value, ok := b.FirstEntry()
+	if ok {
+		b.deleteItem(key, removeMin)
+	}
+	return key, value, ok
+}
+
+// PollLast 删除并返回最大的键值对，BTree为空时返回false
+func (b *BTree[K, V]) PollLast() (K, V, bool) {
+	key, value, ok := b.LastEntry()
+	if ok {
+		b.deleteItem(key, removeMax)
+	}
+	return key, value, ok
+}
+
+func (b *BTree[K, V]) maxItems() int {
+	return 2*b.degree - 1
+}
+
+func (b *BTree[K, V]) minItems() int {
+	return b.degree - 1
+}
+
+func (b *BTree[K, V]) newNode() *btreeNode[K, V] {
+	return &btreeNode[K, V]{cow: b.cow}
+}
+
+// mutable 返回可以修改的 n，n 不属于当前树时复制一份
+func (b *BTree[K, V]) mutable(n *btreeNode[K, V]) *btreeNode[K, V] {
+	if n.cow == b.cow {
+		return n
+	}
+	out := b.newNode()
+	out.items = append(make([]btreeItem[K, V], 0, len(n.items)), n.items...)
+	if len(n.children) > 0 {
+		out.children = append(make([]*btreeNode[K, V], 0, len(n.children)), n.children...)
+	}
+	return out
+}
+
+// mutableChild 返回 n 的第 i 个可以修改的子节点，n 必须是可以修改的
+func (b *BTree[K, V]) mutableChild(n *btreeNode[K, V], i int) *btreeNode[K, V] {
+	c := b.mutable(n.children[i])
+	n.children[i] = c
+	return c
+}
+
+// find 返回 n 中第一个大于等于 key 的位置，以及该位置上的键是否等于 key
+func (b *BTree[K, V]) find(n *btreeNode[K, V], key K) (int, bool) {
+	i := sort.Search(len(n.items), func(i int) bool {
+		return b.compare(n.items[i].key, key) >= 0
+	})
+	return i, i < len(n.items) && b.compare(n.items[i].key, key) == 0
+}
+
+// split 在位置 i 把 n 拆成两个节点，返回位置 i 上的键值对和拆出来的右半部分
+func (b *BTree[K, V]) split(n *btreeNode[K, V], i int) (btreeItem[K, V], *btreeNode[K, V]) {
+	item := n.items[i]
+	next := b.newNode()
+	next.items = append(make([]btreeItem[K, V], 0, len(n.items)-i-1), n.items[i+1:]...)
+	n.items = truncateItems(n.items, i)
+	if len(n.children) > 0 {
+		next.children = append(make([]*btreeNode[K, V], 0, len(n.children)-i-1), n.children[i+1:]...)
+		n.children = truncateChildren(n.children, i+1)
+	}
+	return item, next
+}
+
+// insert 把 item 插入以 n 为根的子树，n 必须是可以修改的并且没有满。返回是否新增了键值对
+func (b *BTree[K, V]) insert(n *btreeNode[K, V], item btreeItem[K, V]) bool {
+	i, found := b.find(n, item.key)
+	if found {
+		n.items[i] = item
+		return false
+	}
+	if len(n.children) == 0 {
+		n.items = insertAt(n.items, i, item)
+		return true
+	}
+	// 子节点已满时先拆分，保证递归下去的节点一定能放下新的键值对
+	if len(n.children[i].items) >= b.maxItems() {
+		mid, second := b.split(b.mutableChild(n, i), b.maxItems()/2)
+		n.items = insertAt(n.items, i, mid)
+		n.children = insertAt(n.children, i+1, second)
+		cmp := b.compare(item.key, mid.key)
+		if cmp == 0 {
+			n.items[i] = item
+			return false
+		}
+		if cmp > 0 {
+			i++
+		}
+	}
+	return b.insert(b.mutableChild(n, i), item)
+}
+
+func (b *BTree[K, V]) deleteItem(key K, typ removeType) (V, bool) {
+	if b.root == nil {
+		var zero V
+		return zero, false
+	}
+	b.root = b.mutable(b.root)
+	item, ok := b.remove(b.root, key, typ)
+	if len(b.root.items) == 0 {
+		if len(b.root.children) > 0 {
+			b.root = b.root.children[0]
+		} else {
+			b.root = nil
+		}
+	}
+	if ok {
+		b.length--
+	}
+	return item.value, ok
+}
+
+// remove 从以 n 为根的子树中删除键值对，n 必须是可以修改的
+// 递归下去之前先保证子节点至少有 minItems+1 个键值对，这样删除之后子节点不会少于最小数量
+func (b *BTree[K, V]) remove(n *btreeNode[K, V], key K, typ removeType) (btreeItem[K, V], bool) {
+	var i int
+	var found bool
+	switch typ {
+	case removeMax:
+		if len(n.children) == 0 {
+			item := n.items[len(n.items)-1]
+			n.items = truncateItems(n.items, len(n.items)-1)
+			return item, true
+		}
+		i = len(n.items)
+	case removeMin:
+		if len(n.children) == 0 {
+			item := n.items[0]
+			n.items = removeAt(n.items, 0)
+			return item, true
+		}
+		i = 0
+	default:
+		i, found = b.find(n, key)
+		if len(n.children) == 0 {
+			if !found {
+				return btreeItem[K, V]{}, false
+			}
+			item := n.items[i]
+			n.items = removeAt(n.items, i)
+			return item, true
+		}
+	}
+	if len(n.children[i].items) <= b.minItems() {
+		b.growChild(n, i)
+		return b.remove(n, key, typ)
+	}
+	child := b.mutableChild(n, i)
+	if found {
+		// 用左子树中最大的键值对（前驱）替换当前的键值对
+		item := n.items[i]
+		n.items[i], _ = b.remove(child, key, removeMax)
+		return item, true
+	}
+	return b.remove(child, key, typ)
+}
+
+// growChild 让 n 的第 i 个子节点多出一个键值对：优先从左右兄弟节点借，兄弟节点都不够时和兄弟节点合并
+func (b *BTree[K, V]) growChild(n *btreeNode[K, V], i int) {
+	if i > 0 && len(n.children[i-1].items) > b.minItems() {
+		child, left := b.mutableChild(n, i), b.mutableChild(n, i-1)
+		stolen := left.items[len(left.items)-1]
+		left.items = truncateItems(left.items, len(left.items)-1)
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = stolen
+		if len(left.children) > 0 {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = truncateChildren(left.children, len(left.children)-1)
+		}
+		return
+	}
+	if i < len(n.items) && len(n.children[i+1].items) > b.minItems() {
+		child, right := b.mutableChild(n, i), b.mutableChild(n, i+1)
+		stolen := right.items[0]
+		right.items = removeAt(right.items, 0)
+		child.items = append(child.items, n.items[i])
+		n.items[i] = stolen
+		if len(right.children) > 0 {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+		return
+	}
+	if i >= len(n.items) {
+		i--
+	}
+	child := b.mutableChild(n, i)
+	mergeItem := n.items[i]
+	mergeChild := n.children[i+1]
+	n.items = removeAt(n.items, i)
+	n.children = removeAt(n.children, i+1)
+	child.items = append(child.items, mergeItem)
+	child.items = append(child.items, mergeChild.items...)
+	child.children = append(child.children, mergeChild.children...)
+}
+
+// ascend 中序遍历以 n 为根的子树，from 不为 nil 时跳过小于 from（inclusive 为 false 时小于等于）的键
+// fn 返回 false 时停止遍历，并返回 false
+func (b *BTree[K, V]) ascend(n *btreeNode[K, V], from *K, inclusive bool, fn func(key K, value V) bool) bool {
+	i := 0
+	if from != nil {
+		i, _ = b.find(n, *from)
+	}
+	for ; i < len(n.items); i++ {
+		if len(n.children) > 0 && !b.ascend(n.children[i], from, inclusive, fn) {
+			return false
+		}
+		item := n.items[i]
+		if from != nil && !inclusive && b.compare(item.key, *from) == 0 {
+			continue
+		}
+		if !fn(item.key, item.value) {
+			return false
+		}
+	}
+	if len(n.children) > 0 {
+		return b.ascend(n.children[len(n.children)-1], from, inclusive, fn)
+	}
+	return true
+}
+
+// floor 返回小于（inclusive 为 true 时小于等于）key 的最大的键值对
+func (b *BTree[K, V]) floor(key K, inclusive bool) (K, V, bool) {
+	var res *btreeItem[K, V]
+	for n := b.root; n != nil; {
+		i, found := b.find(n, key)
+		if found && inclusive {
+			return n.items[i].key, n.items[i].value, true
+		}
+		if i > 0 {
+			res = &n.items[i-1]
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	if res == nil {
+		return b.noEntry()
+	}
+	return res.key, res.value, true
+}
+
+// ceiling 返回大于（inclusive 为 true 时大于等于）key 的最小的键值对
+func (b *BTree[K, V]) ceiling(key K, inclusive bool) (K, V, bool) {
+	var res *btreeItem[K, V]
+	for n := b.root; n != nil; {
+		i, found := b.find(n, key)
+		if found {
+			if inclusive {
+				return n.items[i].key, n.items[i].value, true
+			}
+			i++
+		}
+		if i < len(n.items) {
+			res = &n.items[i]
+		}
+		if len(n.children) == 0 {
+			break
+		}
+		n = n.children[i]
+	}
+	if res == nil {
+		return b.noEntry()
+	}
+	return res.key, res.value, true
+}
+
+func (b *BTree[K, V]) noEntry() (K, V, bool) {
+	var key K
+	var value V
+	return key, value, false
+}
+
+// insertAt 在位置 i 插入 v
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// removeAt 删除位置 i 上的元素，并把空出来的位置置零，避免内存泄漏
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+// truncateItems 只保留前 n 个键值对，并把后面的位置置零
+func truncateItems[K any, V any](items []btreeItem[K, V], n int) []btreeItem[K, V] {
+	for i := n; i < len(items); i++ {
+		items[i] = btreeItem[K, V]{}
+	}
+	return items[:n]
+}
+
+// truncateChildren 只保留前 n 个子节点，并把后面的位置置零
+func truncateChildren[K any, V any](children []*btreeNode[K, V], n int) []*btreeNode[K, V] {
+	for i := n; i < len(children); i++ {
+		children[i] = nil
+	}
+	return children[:n]
+}
@@ -0,0 +1,372 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewBTree(t *testing.T) {
+	testCases := []struct {
+		name    string
+		degree  int
+		compare func(src, dst int) int
+		wantErr error
+	}{
+		{name: "nil comparator", degree: 2, wantErr: errBTreeComparatorIsNull},
+		{name: "degree too small", degree: 1, compare: compare(), wantErr: errBTreeDegreeTooSmall},
+		{name: "ok", degree: 2, compare: compare()},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBTree[int, int](tc.degree, tc.compare)
+			assert.Equal(t, tc.wantErr, err)
+			if err == nil {
+				assert.Equal(t, 0, b.Len())
+				assert.Equal(t, []int{}, b.Keys())
+			}
+		})
+	}
+}
+
+func TestNewBTreeWithSorted(t *testing.T) {
+	testCases := []struct {
+		name    string
+		degree  int
+		keys    []int
+		values  []int
+		wantErr error
+	}{
+		{name: "length mismatch", degree: 2, keys: []int{1, 2}, values: []int{1}, wantErr: errBTreeLengthMismatch},
+		{name: "not sorted", degree: 2, keys: []int{1, 3, 2}, values: []int{1, 3, 2}, wantErr: errBTreeKeysNotSorted},
+		{name: "duplicates", degree: 2, keys: []int{1, 1}, values: []int{1, 1}, wantErr: errBTreeKeysNotSorted},
+		{name: "empty", degree: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewBTreeWithSorted[int, int](tc.degree, compare(), tc.keys, tc.values)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+
+	for _, degree := range []int{2, 3, 8} {
+		for n := 1; n <= 300; n++ {
+			keys := make([]int, n)
+			values := make([]int, n)
+			for i := range keys {
+				keys[i] = i * 2
+				values[i] = i * 20
+			}
+			b, err := NewBTreeWithSorted[int, int](degree, compare(), keys, values)
+			assert.NoError(t, err)
+			assert.True(t, isBTree(b), "degree %d, n %d", degree, n)
+			assert.Equal(t, keys, b.Keys())
+			assert.Equal(t, values, b.Values())
+			assert.Equal(t, n, b.Len())
+			// 批量构建之后可以继续修改
+			assert.NoError(t, b.Put(-1, -1))
+			b.Delete(0)
+			assert.True(t, isBTree(b))
+		}
+	}
+}
+
+func TestBTree_Random(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, 16} {
+		rnd := rand.New(rand.NewSource(int64(degree)))
+		b, err := NewBTree[int, int](degree, compare())
+		assert.NoError(t, err)
+		tm, err := NewTreeMap[int, int](compare())
+		assert.NoError(t, err)
+		for i := 0; i < 5000; i++ {
+			key := rnd.Intn(500)
+			if rnd.Intn(3) == 0 {
+				v1, ok1 := b.Delete(key)
+				v2, ok2 := tm.Delete(key)
+				assert.Equal(t, ok2, ok1)
+				assert.Equal(t, v2, v1)
+			} else {
+				assert.NoError(t, b.Put(key, i))
+				assert.NoError(t, tm.Put(key, i))
+			}
+		}
+		assert.True(t, isBTree(b))
+		assert.Equal(t, tm.Len(), b.Len())
+		assert.Equal(t, tm.Keys(), b.Keys())
+		assert.Equal(t, tm.Values(), b.Values())
+
+		// 逐个删除直到为空
+		for _, key := range tm.Keys() {
+			_, ok := b.Delete(key)
+			assert.True(t, ok)
+		}
+		assert.True(t, isBTree(b))
+		assert.Equal(t, 0, b.Len())
+		assert.Nil(t, b.root)
+	}
+}
+
+func TestBTree_Navigable(t *testing.T) {
+	b, err := NewBTree[int, int](2, compare())
+	assert.NoError(t, err)
+	_, _, ok := b.FirstEntry()
+	assert.False(t, ok)
+	_, _, ok = b.LastEntry()
+	assert.False(t, ok)
+	_, ok = b.FloorKey(1)
+	assert.False(t, ok)
+
+	for i := 10; i <= 100; i += 10 {
+		assert.NoError(t, b.Put(i, i*10))
+	}
+	testCases := []struct {
+		name    string
+		find    func(key int) (int, bool)
+		key     int
+		wantKey int
+		wantOk  bool
+	}{
+		{name: "floor exact", find: b.FloorKey, key: 50, wantKey: 50, wantOk: true},
+		{name: "floor between", find: b.FloorKey, key: 55, wantKey: 50, wantOk: true},
+		{name: "floor none", find: b.FloorKey, key: 5},
+		{name: "floor beyond", find: b.FloorKey, key: 500, wantKey: 100, wantOk: true},
+		{name: "lower exact", find: b.LowerKey, key: 50, wantKey: 40, wantOk: true},
+		{name: "lower none", find: b.LowerKey, key: 10},
+		{name: "ceiling exact", find: b.CeilingKey, key: 30, wantKey: 30, wantOk: true},
+		{name: "ceiling between", find: b.CeilingKey, key: 25, wantKey: 30, wantOk: true},
+		{name: "ceiling none", find: b.CeilingKey, key: 105},
+		{name: "higher exact", find: b.HigherKey, key: 30, wantKey: 40, wantOk: true},
+		{name: "higher none", find: b.HigherKey, key: 100},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, ok := tc.find(tc.key)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantKey, key)
+		})
+	}
+
+	// 每个 key 都和 TreeMap 的结果对比
+	tm, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for i := 10; i <= 100; i += 10 {
+		assert.NoError(t, tm.Put(i, i*10))
+	}
+	for key := 0; key <= 110; key++ {
+		k1, v1, ok1 := b.FloorEntry(key)
+		k2, v2, ok2 := tm.FloorEntry(key)
+		assert.Equal(t, []any{k2, v2, ok2}, []any{k1, v1, ok1})
+		k1, v1, ok1 = b.HigherEntry(key)
+		k2, v2, ok2 = tm.HigherEntry(key)
+		assert.Equal(t, []any{k2, v2, ok2}, []any{k1, v1, ok1})
+	}
+
+	k, v, ok := b.PollFirst()
+	assert.True(t, ok)
+	assert.Equal(t, []int{10, 100}, []int{k, v})
+	k, v, ok = b.PollLast()
+	assert.True(t, ok)
+	assert.Equal(t, []int{100, 1000}, []int{k, v})
+	k, _, _ = b.FirstEntry()
+	assert.Equal(t, 20, k)
+	k, _, _ = b.LastEntry()
+	assert.Equal(t, 90, k)
+	assert.Equal(t, 8, b.Len())
+	assert.True(t, isBTree(b))
+}
+
+func TestBTree_AscendRange(t *testing.T) {
+	testCases := []struct {
+		name          string
+		from          int
+		fromInclusive bool
+		to            int
+		toInclusive   bool
+		wantKeys      []int
+	}{
+		{name: "closed", from: 4, fromInclusive: true, to: 10, toInclusive: true, wantKeys: []int{4, 6, 8, 10}},
+		{name: "open", from: 4, to: 10, wantKeys: []int{6, 8}},
+		{name: "between keys", from: 3, to: 9, wantKeys: []int{4, 6, 8}},
+		{name: "empty", from: 5, to: 6, wantKeys: []int{}},
+		{name: "from greater than to", from: 8, fromInclusive: true, to: 2, toInclusive: true, wantKeys: []int{}},
+		{name: "all", from: -1, to: 100, wantKeys: []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}},
+	}
+	b, err := NewBTree[int, int](2, compare())
+	assert.NoError(t, err)
+	for i := 0; i < 20; i += 2 {
+		assert.NoError(t, b.Put(i, i))
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := make([]int, 0)
+			b.AscendRange(tc.from, tc.fromInclusive, tc.to, tc.toInclusive, func(key int, value int) bool {
+				keys = append(keys, key)
+				return true
+			})
+			assert.Equal(t, tc.wantKeys, keys)
+		})
+	}
+
+	keys := make([]int, 0)
+	b.AscendFrom(10, false, func(key int, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	assert.Equal(t, []int{12, 14, 16}, keys)
+}
+
+func TestBTree_DeleteRange(t *testing.T) {
+	testCases := []struct {
+		name          string
+		from          int
+		fromInclusive bool
+		to            int
+		toInclusive   bool
+		wantCount     int
+		wantKeys      []int
+	}{
+		{name: "closed", from: 2, fromInclusive: true, to: 5, toInclusive: true, wantCount: 4, wantKeys: []int{0, 1, 6, 7, 8, 9}},
+		{name: "open", from: 2, to: 5, wantCount: 2, wantKeys: []int{0, 1, 2, 5, 6, 7, 8, 9}},
+		{name: "none", from: 20, fromInclusive: true, to: 30, toInclusive: true, wantKeys: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "all", from: 0, fromInclusive: true, to: 9, toInclusive: true, wantCount: 10, wantKeys: []int{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBTree[int, int](2, compare())
+			assert.NoError(t, err)
+			for i := 0; i < 10; i++ {
+				assert.NoError(t, b.Put(i, i))
+			}
+			count := b.DeleteRange(tc.from, tc.fromInclusive, tc.to, tc.toInclusive)
+			assert.Equal(t, tc.wantCount, count)
+			assert.Equal(t, tc.wantKeys, b.Keys())
+			assert.Equal(t, len(tc.wantKeys), b.Len())
+			assert.True(t, isBTree(b))
+		})
+	}
+}
+
+func TestBTree_Clone(t *testing.T) {
+	b, err := NewBTree[int, int](2, compare())
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, b.Put(i, i))
+	}
+	clone := b.Clone()
+	// Clone 之后两棵树共享同一个根节点
+	assert.Same(t, b.root, clone.root)
+
+	for i := 0; i < 100; i += 2 {
+		b.Delete(i)
+	}
+	assert.NoError(t, b.Put(1, -1))
+	assert.NoError(t, clone.Put(1000, 1000))
+	assert.NotSame(t, b.root, clone.root)
+
+	assert.Equal(t, 50, b.Len())
+	assert.Equal(t, 101, clone.Len())
+	v, _ := b.Get(1)
+	assert.Equal(t, -1, v)
+	v, _ = clone.Get(1)
+	assert.Equal(t, 1, v)
+	assert.False(t, b.Contains(1000))
+	assert.True(t, clone.Contains(0))
+	assert.True(t, isBTree(b))
+	assert.True(t, isBTree(clone))
+
+	// 对副本再次 Clone，原来的树依然不受影响
+	clone2 := clone.Clone()
+	clone2.Clear()
+	clone.DeleteRange(0, true, 49, true)
+	assert.Equal(t, 0, clone2.Len())
+	assert.Equal(t, 51, clone.Len())
+	assert.Equal(t, 50, b.Len())
+	assert.True(t, isBTree(clone))
+}
+
+// isBTree 校验有序性、每个节点的键值对数量、子节点数量以及所有叶子节点在同一层
+func isBTree[K any, V any](b *BTree[K, V]) bool {
+	if b.root == nil {
+		return b.length == 0
+	}
+	count := 0
+	leafDepth := -1
+	var check func(n *btreeNode[K, V], depth int, isRoot bool) bool
+	check = func(n *btreeNode[K, V], depth int, isRoot bool) bool {
+		if len(n.items) > b.maxItems() || (!isRoot && len(n.items) < b.minItems()) || len(n.items) == 0 {
+			return false
+		}
+		for i := 1; i < len(n.items); i++ {
+			if b.compare(n.items[i-1].key, n.items[i].key) >= 0 {
+				return false
+			}
+		}
+		count += len(n.items)
+		if len(n.children) == 0 {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+			return leafDepth == depth
+		}
+		if len(n.children) != len(n.items)+1 {
+			return false
+		}
+		for i, c := range n.children {
+			if i > 0 && b.compare(c.items[0].key, n.items[i-1].key) <= 0 {
+				return false
+			}
+			if i < len(n.items) && b.compare(c.items[len(c.items)-1].key, n.items[i].key) >= 0 {
+				return false
+			}
+			if !check(c, depth+1, false) {
+				return false
+			}
+		}
+		return true
+	}
+	return check(b.root, 0, true) && count == b.length
+}
+
+// BenchmarkBTree 对比 BTree 和 TreeMap 在大量键时的性能
+func BenchmarkBTree(b *testing.B) {
+	const keys = 1 << 20
+	rnd := rand.New(rand.NewSource(1))
+	data := make([]int, keys)
+	for i := range data {
+		data[i] = rnd.Int()
+	}
+	b.Run("BTree put", func(b *testing.B) {
+		bt, _ := NewBTree[int, int](32, compare())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = bt.Put(data[i&(keys-1)], i)
+		}
+	})
+	b.Run("TreeMap put", func(b *testing.B) {
+		m, _ := NewTreeMap[int, int](compare())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.Put(data[i&(keys-1)], i)
+		}
+	})
+	b.Run("BTree get", func(b *testing.B) {
+		bt, _ := NewBTree[int, int](32, compare())
+		for i, k := range data {
+			_ = bt.Put(k, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = bt.Get(data[i&(keys-1)])
+		}
+	})
+	b.Run("TreeMap get", func(b *testing.B) {
+		m, _ := NewTreeMap[int, int](compare())
+		for i, k := range data {
+			_ = m.Put(k, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = m.Get(data[i&(keys-1)])
+		}
+	})
+}
@@ -1,38 +1,73 @@
 package mapx
 
-// builtinMap 是对 map 的二次封装
+var _ Map[string, any] = (*BuiltinMap[string, any])(nil)
+
+// BuiltinMap 是对 map 的二次封装
 // 主要用于各种装饰器模式中被装饰的那个
-type builtinMap[K comparable, V any] struct {
+type BuiltinMap[K comparable, V any] struct {
 	data map[K]V
 }
 
-func (b *builtinMap[K, V]) Put(key K, val V) error {
+// NewBuiltinMap 创建一个 BuiltinMap，cap 是底层 map 的初始容量
+func NewBuiltinMap[K comparable, V any](cap int) *BuiltinMap[K, V] {
+	return &BuiltinMap[K, V]{
+		data: make(map[K]V, cap),
+	}
+}
+
+// NewBuiltinMapOf 使用 data 作为底层的 map 创建 BuiltinMap，不会复制 data
+func NewBuiltinMapOf[K comparable, V any](data map[K]V) *BuiltinMap[K, V] {
+	if data == nil {
+		data = make(map[K]V)
+	}
+	return &BuiltinMap[K, V]{data: data}
+}
+
+func (b *BuiltinMap[K, V]) Put(key K, val V) error {
 	b.data[key] = val
 	return nil
 }
 
-func (b *builtinMap[K, V]) Get(key K) (V, bool) {
+func (b *BuiltinMap[K, V]) Get(key K) (V, bool) {
 	val, ok := b.data[key]
 	return val, ok
 }
 
-func (b *builtinMap[K, V]) Delete(k K) (V, bool) {
+func (b *BuiltinMap[K, V]) Delete(k K) (V, bool) {
 	v, ok := b.data[k]
 	delete(b.data, k)
 	return v, ok
 }
 
 // Keys 返回的 key 是随机的。即便对于同一个实例，调用两次，得到的结果都可能不同。
-func (b *builtinMap[K, V]) Keys() []K {
+func (b *BuiltinMap[K, V]) Keys() []K {
 	return Keys[K, V](b.data)
 }
 
-func (b *builtinMap[K, V]) Values() []V {
+func (b *BuiltinMap[K, V]) Values() []V {
 	return Values[K, V](b.data)
 }
 
-func newBuiltinMap[K comparable, V any](cap int) *builtinMap[K, V] {
-	return &builtinMap[K, V]{
-		data: make(map[K]V, cap),
+func (b *BuiltinMap[K, V]) Len() int {
+	return len(b.data)
+}
+
+func (b *BuiltinMap[K, V]) Contains(key K) bool {
+	_, ok := b.data[key]
+	return ok
+}
+
+func (b *BuiltinMap[K, V]) Clear() {
+	for k := range b.data {
+		delete(b.data, k)
+	}
+}
+
+// Range 遍历的顺序是随机的
+func (b *BuiltinMap[K, V]) Range(fn func(key K, value V) bool) {
+	for k, v := range b.data {
+		if !fn(k, v) {
+			return
+		}
 	}
 }
@@ -39,7 +39,7 @@ func TestBuiltinMap_Delete(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := newBuiltinMapOf[string, string](tc.m)
+			m := NewBuiltinMapOf[string, string](tc.m)
 			value, isDelete := m.Delete(tc.target)
 			assert.Equal(t, tc.isDeleted, isDelete)
 			assert.Equal(t, tc.wantValue, value)
@@ -83,7 +83,7 @@ func TestBuiltinMap_Get(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := newBuiltinMapOf[string, string](tc.m)
+			m := NewBuiltinMapOf[string, string](tc.m)
 			value, isFound := m.Get(tc.target)
 			assert.Equal(t, tc.isFound, isFound)
 			assert.Equal(t, tc.wantValue, value)
@@ -107,7 +107,7 @@ func TestBuiltinMap_Put(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := newBuiltinMap[string, string](tc.cap)
+			m := NewBuiltinMap[string, string](tc.cap)
 			err := m.Put(tc.key, tc.value)
 			assert.Equal(t, tc.wantErr, err)
 			value, ok := m.data[tc.key]
@@ -144,7 +144,7 @@ func TestBuiltinMap_Keys(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := newBuiltinMapOf[string, string](tc.m)
+			m := NewBuiltinMapOf[string, string](tc.m)
 			keys := m.Keys()
 			assert.ElementsMatch(t, tc.wantKeys, keys)
 		})
@@ -178,13 +178,9 @@ func TestBuiltinMap_Values(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := newBuiltinMapOf[string, string](tc.m)
+			m := NewBuiltinMapOf[string, string](tc.m)
 			values := m.Values()
 			assert.ElementsMatch(t, tc.wantValues, values)
 		})
 	}
 }
-
-func newBuiltinMapOf[K comparable, V any](data map[K]V) *builtinMap[K, V] {
-	return &builtinMap[K, V]{data: data}
-}
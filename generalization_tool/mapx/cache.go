@@ -0,0 +1,138 @@
+package mapx
+
+import "errors"
+
+var errCacheInvalidCapacity = errors.New("Cache：capacity必须大于0")
+
+var (
+	_ Cache[any, any] = (*LRU[any, any])(nil)
+	_ Cache[any, any] = (*LFU[any, any])(nil)
+	_ Cache[any, any] = (*ARC[any, any])(nil)
+)
+
+// Cache 有容量限制的缓存，不同的实现采用不同的淘汰策略
+// - Get 会被计入命中/未命中次数，并可能影响淘汰顺序
+// - Put 超出容量时按照淘汰策略淘汰元素
+// - Delete 和 Clear 不会触发淘汰回调，Contains 和 Range 不会影响淘汰顺序
+// 所有实现都是非线程安全的
+type Cache[K any, V any] interface {
+	Map[K, V]
+	// Stats 返回 Get 的命中统计
+	Stats() CacheStats
+}
+
+// CacheStats 缓存命中统计
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// HitRate 返回命中率，没有任何访问时返回0
+func (c CacheStats) HitRate() float64 {
+	total := c.Hits + c.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(c.Hits) / float64(total)
+}
+
+// cacheStats 嵌入到各个缓存实现中，用于记录命中统计
+type cacheStats struct {
+	hits   uint64
+	misses uint64
+}
+
+func (c *cacheStats) record(hit bool) {
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+// Stats 返回 Get 的命中统计
+func (c *cacheStats) Stats() CacheStats {
+	return CacheStats{Hits: c.hits, Misses: c.misses}
+}
+
+// ResetStats 清空命中统计
+func (c *cacheStats) ResetStats() {
+	c.hits, c.misses = 0, 0
+}
+
+// cacheEntry 缓存链表中的节点
+type cacheEntry[K any, V any] struct {
+	key   K
+	value V
+	// freq 访问频率，仅 LFU 使用
+	freq int
+	// list 节点当前所在的链表
+	list *cacheList[K, V]
+	prev *cacheEntry[K, V]
+	next *cacheEntry[K, V]
+}
+
+// cacheList 带哨兵节点的双向循环链表，头部是最久的节点，尾部是最新的节点
+type cacheList[K any, V any] struct {
+	root   cacheEntry[K, V]
+	length int
+}
+
+func newCacheList[K any, V any]() *cacheList[K, V] {
+	l := &cacheList[K, V]{}
+	l.root.prev, l.root.next = &l.root, &l.root
+	return l
+}
+
+// pushBack 将节点放入链表尾部，节点不能在其它链表中
+func (l *cacheList[K, V]) pushBack(e *cacheEntry[K, V]) {
+	e.prev, e.next = l.root.prev, &l.root
+	e.prev.next, e.next.prev = e, e
+	e.list = l
+	l.length++
+}
+
+// remove 将节点从链表中移除
+func (l *cacheList[K, V]) remove(e *cacheEntry[K, V]) {
+	e.prev.next, e.next.prev = e.next, e.prev
+	e.prev, e.next, e.list = nil, nil, nil
+	l.length--
+}
+
+// moveToBack 将链表中的节点移动到尾部
+func (l *cacheList[K, V]) moveToBack(e *cacheEntry[K, V]) {
+	l.remove(e)
+	l.pushBack(e)
+}
+
+// front 返回链表头部的节点，链表为空时返回nil
+func (l *cacheList[K, V]) front() *cacheEntry[K, V] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// appendKeys 从头到尾将链表中的键追加到 keys 中
+func (l *cacheList[K, V]) appendKeys(keys []K) []K {
+	for e := l.root.next; e != &l.root; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// rangeEntries 从头到尾遍历链表，fn 返回 false 时停止遍历并返回 false
+func (l *cacheList[K, V]) rangeEntries(fn func(key K, value V) bool) bool {
+	for e := l.root.next; e != &l.root; e = e.next {
+		if !fn(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// clear 清空链表
+func (l *cacheList[K, V]) clear() {
+	l.root.prev, l.root.next = &l.root, &l.root
+	l.length = 0
+}
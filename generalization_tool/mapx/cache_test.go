@@ -0,0 +1,85 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestCacheStats_HitRate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		stats CacheStats
+		want  float64
+	}{
+		{
+			name:  "no access",
+			stats: CacheStats{},
+			want:  0,
+		},
+		{
+			name:  "all hit",
+			stats: CacheStats{Hits: 3},
+			want:  1,
+		},
+		{
+			name:  "half",
+			stats: CacheStats{Hits: 2, Misses: 2},
+			want:  0.5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.stats.HitRate())
+		})
+	}
+}
+
+// TestCache_Trace 在同一份访问记录上回放不同的淘汰策略，并校验通用的行为
+// 访问记录由热点数据和周期性的顺序扫描组成
+func TestCache_Trace(t *testing.T) {
+	const capacity = 50
+	trace := cacheTrace(20000)
+	lru, err := NewTreeMapLRU[int, int](capacity, compare())
+	assert.NoError(t, err)
+	lfu, err := NewTreeMapLFU[int, int](capacity, compare())
+	assert.NoError(t, err)
+	arc, err := NewTreeMapARC[int, int](capacity, compare())
+	assert.NoError(t, err)
+
+	caches := map[string]Cache[int, int]{
+		"lru": lru,
+		"lfu": lfu,
+		"arc": arc,
+	}
+	stats := make(map[string]CacheStats, len(caches))
+	for name, c := range caches {
+		for _, key := range trace {
+			if _, ok := c.Get(key); !ok {
+				assert.NoError(t, c.Put(key, key))
+			}
+			assert.LessOrEqual(t, c.Len(), capacity)
+		}
+		assert.Equal(t, capacity, len(c.Keys()))
+		stats[name] = c.Stats()
+		assert.Equal(t, uint64(len(trace)), stats[name].Hits+stats[name].Misses)
+	}
+	// 扫描会冲刷 LRU 中的热点数据，而 ARC 能够保留它们
+	assert.Greater(t, stats["arc"].HitRate(), stats["lru"].HitRate())
+}
+
+func cacheTrace(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	trace := make([]int, 0, n)
+	scan := 1000
+	for len(trace) < n {
+		for i := 0; i < 200; i++ {
+			trace = append(trace, r.Intn(30))
+		}
+		for i := 0; i < 100; i++ {
+			trace = append(trace, scan)
+			scan++
+		}
+	}
+	return trace[:n]
+}
@@ -0,0 +1,309 @@
+package mapx
+
+import (
+	"errors"
+	"sync"
+)
+
+const defaultShardCount = 32
+
+var errConcurrentMapHashIsNull = errors.New("ConcurrentMap：hash不能为nil")
+
+var _ Map[any, any] = (*ConcurrentMap[any, any])(nil)
+
+// ConcurrentMap 分段加锁的并发 map
+// 键值对根据 hash(key) 分布到不同的分段，每个分段有自己的读写锁，
+// 不同分段上的操作互不阻塞，适用于高并发的读写场景
+type ConcurrentMap[K any, V any] struct {
+	shards []*mapShard[K, V]
+	hash   func(key K) uint64
+}
+
+type mapShard[K any, V any] struct {
+	lock sync.RWMutex
+	m    Map[K, V]
+	// exclusive 为 true 时读操作也使用写锁，用于 Get 等读方法会修改内部状态的 map
+	exclusive bool
+}
+
+// rLock 读操作加锁
+func (s *mapShard[K, V]) rLock() {
+	if s.exclusive {
+		s.lock.Lock()
+		return
+	}
+	s.lock.RLock()
+}
+
+func (s *mapShard[K, V]) rUnlock() {
+	if s.exclusive {
+		s.lock.Unlock()
+		return
+	}
+	s.lock.RUnlock()
+}
+
+// NewConcurrentMap 创建一个基于内置 map 的分段并发 map
+// shardCount <= 0 时使用默认的分段数量；hash 用于选择分段，不能为nil
+func NewConcurrentMap[K comparable, V any](shardCount int, hash func(key K) uint64) (*ConcurrentMap[K, V], error) {
+	if hash == nil {
+		return nil, errConcurrentMapHashIsNull
+	}
+	return newConcurrentMap[K, V](shardCount, hash, func() Map[K, V] {
+		return NewBuiltinMap[K, V](0)
+	}), nil
+}
+
+// NewConcurrentHashMap 创建一个基于 HashMap 的分段并发 map，使用 Code() 选择分段
+// shardCount <= 0 时使用默认的分段数量
+func NewConcurrentHashMap[K Hashable, V any](shardCount int) *ConcurrentMap[K, V] {
+	return newConcurrentMap[K, V](shardCount, func(key K) uint64 {
+		return key.Code()
+	}, func() Map[K, V] {
+		return NewHashMap[K, V](0)
+	})
+}
+
+// NewConcurrentMapOf 将 m 装饰为线程安全的 map，可以是 HashMap、TreeMap、LinkedMap 等
+// 所有操作共用一把读写锁，因此 Keys 和 Values 保持 m 原有的顺序
+// 读操作（Get、Keys、Values、Len、Contains、Range）共享读锁，所以 m 的读方法不能修改 m；
+// LRU、LFU、ARC 等 Get 会调整内部状态的缓存需要使用 NewConcurrentCacheOf
+// 装饰之后不能再直接操作 m
+func NewConcurrentMapOf[K any, V any](m Map[K, V]) *ConcurrentMap[K, V] {
+	return newSingleShardConcurrentMap[K, V](m, false)
+}
+
+// NewConcurrentCacheOf 将缓存 c 装饰为线程安全的 map
+// 缓存的 Get 会调整访问顺序、访问频率等内部状态，所以读操作同样使用写锁，不同的读操作之间也是互斥的
+// 装饰之后不能再直接操作 c
+func NewConcurrentCacheOf[K any, V any](c Cache[K, V]) *ConcurrentMap[K, V] {
+	return newSingleShardConcurrentMap[K, V](c, true)
+}
+
+func newSingleShardConcurrentMap[K any, V any](m Map[K, V], exclusive bool) *ConcurrentMap[K, V] {
+	return &ConcurrentMap[K, V]{
+		shards: []*mapShard[K, V]{{m: m, exclusive: exclusive}},
+		hash: func(key K) uint64 {
+			return 0
+		},
+	}
+}
+
+func newConcurrentMap[K any, V any](shardCount int, hash func(key K) uint64, newMap func() Map[K, V]) *ConcurrentMap[K, V] {
+	if shardCount <= 0 {
+		shardCount = defaultShardCount
+	}
+	shards := make([]*mapShard[K, V], shardCount)
+	for i := range shards {
+		shards[i] = &mapShard[K, V]{m: newMap()}
+	}
+	return &ConcurrentMap[K, V]{
+		shards: shards,
+		hash:   hash,
+	}
+}
+
+func (c *ConcurrentMap[K, V]) shard(key K) *mapShard[K, V] {
+	return c.shards[c.hash(key)%uint64(len(c.shards))]
+}
+
+// Put 放入键值对，key 已存在时覆盖原来的值
+func (c *ConcurrentMap[K, V]) Put(key K, value V) error {
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m.Put(key, value)
+}
+
+// Get 返回 key 对应的值
+func (c *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+	s := c.shard(key)
+	s.rLock()
+	defer s.rUnlock()
+	return s.m.Get(key)
+}
+
+// Delete 删除 key，并返回原来的值
+func (c *ConcurrentMap[K, V]) Delete(key K) (V, bool) {
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.m.Delete(key)
+}
+
+// Keys 返回所有的键。各个分段依次加锁，因此返回的不是某一时刻的快照
+func (c *ConcurrentMap[K, V]) Keys() []K {
+	res := make([]K, 0)
+	for _, s := range c.shards {
+		s.rLock()
+		res = append(res, s.m.Keys()...)
+		s.rUnlock()
+	}
+	return res
+}
+
+// Values 返回所有的值。各个分段依次加锁，因此返回的不是某一时刻的快照
+func (c *ConcurrentMap[K, V]) Values() []V {
+	res := make([]V, 0)
+	for _, s := range c.shards {
+		s.rLock()
+		res = append(res, s.m.Values()...)
+		s.rUnlock()
+	}
+	return res
+}
+
+// Len 返回键值对数量
+func (c *ConcurrentMap[K, V]) Len() int {
+	length := 0
+	for _, s := range c.shards {
+		s.rLock()
+		length += s.m.Len()
+		s.rUnlock()
+	}
+	return length
+}
+
+// GetOrPut 如果 key 存在，返回已有的值和true；否则放入 value，返回 value 和false
+func (c *ConcurrentMap[K, V]) GetOrPut(key K, value V) (V, bool, error) {
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if actual, ok := s.m.Get(key); ok {
+		return actual, true, nil
+	}
+	return value, false, s.m.Put(key, value)
+}
+
+// ComputeIfAbsent 如果 key 不存在，那么使用 fn 计算出值并放入；fn 返回 error 时不会放入
+// 返回 key 最终对应的值。fn 在持有分段锁的情况下执行，不能再访问当前 map
+func (c *ConcurrentMap[K, V]) ComputeIfAbsent(key K, fn func(key K) (V, error)) (V, error) {
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if actual, ok := s.m.Get(key); ok {
+		return actual, nil
+	}
+	value, err := fn(key)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	return value, s.m.Put(key, value)
+}
+
+// ComputeIfPresent 如果 key 存在，那么使用 fn 根据旧值计算新值；fn 返回的 keep 为 false 时删除 key
+// 返回 key 最终对应的值，以及 key 最终是否存在。fn 在持有分段锁的情况下执行，不能再访问当前 map
+func (c *ConcurrentMap[K, V]) ComputeIfPresent(key K, fn func(key K, value V) (newValue V, keep bool)) (V, bool, error) {
+	var zero V
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	old, ok := s.m.Get(key)
+	if !ok {
+		return zero, false, nil
+	}
+	value, keep := fn(key, old)
+	if !keep {
+		s.m.Delete(key)
+		return zero, false, nil
+	}
+	if err := s.m.Put(key, value); err != nil {
+		return old, true, err
+	}
+	return value, true, nil
+}
+
+// CompareAndSwap 如果 key 当前的值等于 old，那么替换为 new，返回是否替换成功
+// 与 sync.Map 一样，值使用 == 比较，因此值的类型必须是可比较的，否则会 panic
+func (c *ConcurrentMap[K, V]) CompareAndSwap(key K, old V, new V) (bool, error) {
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cur, ok := s.m.Get(key)
+	if !ok || any(cur) != any(old) {
+		return false, nil
+	}
+	if err := s.m.Put(key, new); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CompareAndDelete 如果 key 当前的值等于 old，那么删除 key，返回是否删除成功
+// 与 sync.Map 一样，值使用 == 比较，因此值的类型必须是可比较的，否则会 panic
+func (c *ConcurrentMap[K, V]) CompareAndDelete(key K, old V) bool {
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cur, ok := s.m.Get(key)
+	if !ok || any(cur) != any(old) {
+		return false
+	}
+	s.m.Delete(key)
+	return true
+}
+
+// Contains 判断 key 是否存在
+func (c *ConcurrentMap[K, V]) Contains(key K) bool {
+	s := c.shard(key)
+	s.rLock()
+	defer s.rUnlock()
+	return s.m.Contains(key)
+}
+
+// Clear 删除所有键值对，各个分段依次加锁
+func (c *ConcurrentMap[K, V]) Clear() {
+	for _, s := range c.shards {
+		s.lock.Lock()
+		s.m.Clear()
+		s.lock.Unlock()
+	}
+}
+
+// Range 遍历所有键值对，fn 返回 false 时停止遍历
+// 每个分段遍历的是加读锁时的快照，fn 在锁外执行，因此可以在 fn 中修改当前 map
+func (c *ConcurrentMap[K, V]) Range(fn func(key K, value V) bool) {
+	for _, s := range c.shards {
+		s.rLock()
+		keys, values := make([]K, 0, s.m.Len()), make([]V, 0, s.m.Len())
+		s.m.Range(func(key K, value V) bool {
+			keys = append(keys, key)
+			values = append(values, value)
+			return true
+		})
+		s.rUnlock()
+		for i := range keys {
+			if !fn(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// StringHash 基于 FNV-1a 的字符串哈希函数，可以用于 NewConcurrentMap
+func StringHash(s string) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	hash := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		hash ^= uint64(s[i])
+		hash *= prime64
+	}
+	return hash
+}
+
+// IntegerHash 整数的哈希函数，可以用于 NewConcurrentMap
+// 使用 splitmix64 的混淆步骤，避免连续的整数集中在相邻的分段中
+func IntegerHash[T ~int | ~int8 | ~int16 | ~int32 | ~int64 |
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](key T) uint64 {
+	hash := uint64(key)
+	hash ^= hash >> 30
+	hash *= 0xbf58476d1ce4e5b9
+	hash ^= hash >> 27
+	hash *= 0x94d049bb133111eb
+	hash ^= hash >> 31
+	return hash
+}
@@ -0,0 +1,408 @@
+package mapx
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewConcurrentMap(t *testing.T) {
+	_, err := NewConcurrentMap[int, int](4, nil)
+	assert.Equal(t, errConcurrentMapHashIsNull, err)
+
+	m, err := NewConcurrentMap[int, int](0, IntegerHash[int])
+	assert.NoError(t, err)
+	assert.Equal(t, defaultShardCount, len(m.shards))
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, []int{}, m.Keys())
+	assert.Equal(t, []int{}, m.Values())
+
+	hashMap := NewConcurrentHashMap[testData, int](4)
+	assert.Equal(t, 4, len(hashMap.shards))
+}
+
+func TestConcurrentMap_PutGetDelete(t *testing.T) {
+	m, err := NewConcurrentMap[string, int](4, StringHash)
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, m.Put(strconv.Itoa(i), i))
+	}
+	assert.NoError(t, m.Put("1", 100))
+	assert.Equal(t, 100, m.Len())
+
+	val, ok := m.Get("1")
+	assert.True(t, ok)
+	assert.Equal(t, 100, val)
+	_, ok = m.Get("100")
+	assert.False(t, ok)
+
+	val, ok = m.Delete("2")
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+	_, ok = m.Delete("2")
+	assert.False(t, ok)
+	assert.Equal(t, 99, m.Len())
+	assert.Equal(t, 99, len(m.Keys()))
+	assert.Equal(t, 99, len(m.Values()))
+}
+
+func TestConcurrentMap_Hashable(t *testing.T) {
+	m := NewConcurrentHashMap[testData, string](3)
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, m.Put(newTestData(i), strconv.Itoa(i)))
+	}
+	val, ok := m.Get(newTestData(15))
+	assert.True(t, ok)
+	assert.Equal(t, "15", val)
+	assert.Equal(t, 20, m.Len())
+	assert.ElementsMatch(t, []testData{
+		newTestData(0), newTestData(1), newTestData(2), newTestData(3), newTestData(4),
+		newTestData(5), newTestData(6), newTestData(7), newTestData(8), newTestData(9),
+		newTestData(10), newTestData(11), newTestData(12), newTestData(13), newTestData(14),
+		newTestData(15), newTestData(16), newTestData(17), newTestData(18), newTestData(19),
+	}, m.Keys())
+}
+
+func TestNewConcurrentMapOf(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	assert.NoError(t, treeMap.Put(5, 50))
+	linkedMap := NewLinkedHashMap[testData, int](10)
+	hashMap := NewHashMap[testData, int](10)
+
+	tm := NewConcurrentMapOf[int, int](treeMap)
+	assert.Equal(t, 1, tm.Len())
+	for _, k := range []int{3, 1, 4, 2} {
+		assert.NoError(t, tm.Put(k, k*10))
+	}
+	// 保持 TreeMap 的顺序
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, tm.Keys())
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, tm.Values())
+	assert.Equal(t, 5, tm.Len())
+
+	lm := NewConcurrentMapOf[testData, int](linkedMap)
+	for _, k := range []int{3, 1, 2} {
+		assert.NoError(t, lm.Put(newTestData(k), k))
+	}
+	// 保持 LinkedMap 的插入顺序
+	assert.Equal(t, []testData{newTestData(3), newTestData(1), newTestData(2)}, lm.Keys())
+	val, ok := lm.Delete(newTestData(1))
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, []int{3, 2}, lm.Values())
+
+	hm := NewConcurrentMapOf[testData, int](hashMap)
+	actual, loaded, err := hm.GetOrPut(newTestData(1), 1)
+	assert.NoError(t, err)
+	assert.False(t, loaded)
+	assert.Equal(t, 1, actual)
+	assert.True(t, hm.CompareAndDelete(newTestData(1), 1))
+	assert.Equal(t, 0, hm.Len())
+}
+
+// TestConcurrentMapOf_Concurrent 需要配合 -race 运行
+func TestConcurrentMapOf_Concurrent(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	m := NewConcurrentMapOf[int, int](treeMap)
+	const goroutines, keys = 8, 200
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < keys; i += goroutines {
+				assert.NoError(t, m.Put(i, i))
+				_, _ = m.Get(i)
+				_ = m.Values()
+			}
+		}(g)
+	}
+	wg.Wait()
+	keySlice := m.Keys()
+	assert.Equal(t, keys, len(keySlice))
+	for i, k := range keySlice {
+		assert.Equal(t, i, k)
+	}
+}
+
+// TestConcurrentCacheOf_LRU LRU 的 Get 会调整访问顺序，并发 Get 需要互斥，需要配合 -race 运行
+func TestConcurrentCacheOf_LRU(t *testing.T) {
+	lru, err := NewTreeMapLRU[int, int](50, compare())
+	assert.NoError(t, err)
+	m := NewConcurrentCacheOf[int, int](lru)
+	for i := 0; i < 50; i++ {
+		assert.NoError(t, m.Put(i, i))
+	}
+	const goroutines = 4
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				v, ok := m.Get((i + g) % 50)
+				assert.True(t, ok)
+				assert.Equal(t, (i+g)%50, v)
+				_ = m.Contains(i % 50)
+				_ = m.Keys()
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 50, m.Len())
+}
+
+// TestConcurrentMapOf_SharedRead 持有读锁时，其它的读操作可以同时进行；缓存的读操作需要等待
+func TestConcurrentMapOf_SharedRead(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	lru, err := NewTreeMapLRU[int, int](10, compare())
+	assert.NoError(t, err)
+	testCases := []struct {
+		name       string
+		m          *ConcurrentMap[int, int]
+		wantShared bool
+	}{
+		{name: "TreeMap", m: NewConcurrentMapOf[int, int](treeMap), wantShared: true},
+		{name: "LRU", m: NewConcurrentCacheOf[int, int](lru)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, tc.m.Put(1, 1))
+			s := tc.m.shards[0]
+			// 第一个读者持有读锁
+			s.rLock()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_, _ = tc.m.Get(1)
+				_ = tc.m.Keys()
+			}()
+			select {
+			case <-done:
+				assert.True(t, tc.wantShared)
+				s.rUnlock()
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, tc.wantShared)
+				s.rUnlock()
+				<-done
+			}
+		})
+	}
+}
+
+func TestConcurrentMap_GetOrPut(t *testing.T) {
+	m, err := NewConcurrentMap[int, int](4, IntegerHash[int])
+	assert.NoError(t, err)
+	val, loaded, err := m.GetOrPut(1, 10)
+	assert.NoError(t, err)
+	assert.False(t, loaded)
+	assert.Equal(t, 10, val)
+
+	val, loaded, err = m.GetOrPut(1, 20)
+	assert.NoError(t, err)
+	assert.True(t, loaded)
+	assert.Equal(t, 10, val)
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestConcurrentMap_ComputeIfAbsent(t *testing.T) {
+	m, err := NewConcurrentMap[int, int](4, IntegerHash[int])
+	assert.NoError(t, err)
+	wantErr := errors.New("compute error")
+	testCases := []struct {
+		name    string
+		key     int
+		fn      func(key int) (int, error)
+		wantVal int
+		wantErr error
+		wantLen int
+	}{
+		{
+			name: "absent",
+			key:  1,
+			fn: func(key int) (int, error) {
+				return key * 10, nil
+			},
+			wantVal: 10,
+			wantLen: 1,
+		},
+		{
+			name: "present",
+			key:  1,
+			fn: func(key int) (int, error) {
+				return 100, nil
+			},
+			wantVal: 10,
+			wantLen: 1,
+		},
+		{
+			name: "error",
+			key:  2,
+			fn: func(key int) (int, error) {
+				return 0, wantErr
+			},
+			wantErr: wantErr,
+			wantLen: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := m.ComputeIfAbsent(tc.key, tc.fn)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantLen, m.Len())
+		})
+	}
+}
+
+func TestConcurrentMap_ComputeIfPresent(t *testing.T) {
+	m, err := NewConcurrentMap[int, int](4, IntegerHash[int])
+	assert.NoError(t, err)
+	assert.NoError(t, m.Put(1, 1))
+	assert.NoError(t, m.Put(2, 2))
+
+	_, ok, err := m.ComputeIfPresent(3, func(key int, value int) (int, bool) {
+		t.Fatal("不应该执行")
+		return 0, false
+	})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	val, ok, err := m.ComputeIfPresent(1, func(key int, value int) (int, bool) {
+		return value + 10, true
+	})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 11, val)
+
+	_, ok, err = m.ComputeIfPresent(2, func(key int, value int) (int, bool) {
+		return 0, false
+	})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, ok = m.Get(2)
+	assert.False(t, ok)
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestConcurrentMap_CompareAndSwap(t *testing.T) {
+	m, err := NewConcurrentMap[int, string](4, IntegerHash[int])
+	assert.NoError(t, err)
+	swapped, err := m.CompareAndSwap(1, "a", "b")
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+
+	assert.NoError(t, m.Put(1, "a"))
+	swapped, err = m.CompareAndSwap(1, "x", "b")
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+	swapped, err = m.CompareAndSwap(1, "a", "b")
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+
+	assert.False(t, m.CompareAndDelete(1, "a"))
+	assert.False(t, m.CompareAndDelete(2, "b"))
+	assert.True(t, m.CompareAndDelete(1, "b"))
+	assert.Equal(t, 0, m.Len())
+}
+
+// TestConcurrentMap_Concurrent 需要配合 -race 运行
+func TestConcurrentMap_Concurrent(t *testing.T) {
+	m, err := NewConcurrentMap[int, int](8, IntegerHash[int])
+	assert.NoError(t, err)
+	const goroutines, keys = 16, 1000
+	var computed int64
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < keys; i++ {
+				_, err := m.ComputeIfAbsent(i, func(key int) (int, error) {
+					atomic.AddInt64(&computed, 1)
+					return 0, nil
+				})
+				assert.NoError(t, err)
+				// 每个 goroutine 都对所有 key 加1，CAS 失败时重试
+				for {
+					cur, _ := m.Get(i)
+					swapped, err := m.CompareAndSwap(i, cur, cur+1)
+					assert.NoError(t, err)
+					if swapped {
+						break
+					}
+				}
+				_ = m.Len()
+				_ = m.Keys()[:0]
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, int64(keys), computed)
+	assert.Equal(t, keys, m.Len())
+	for i := 0; i < keys; i++ {
+		val, ok := m.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, goroutines, val)
+	}
+}
+
+func BenchmarkConcurrentMap(b *testing.B) {
+	const keys = 1 << 10
+	m, _ := NewConcurrentMap[int, int](0, IntegerHash[int])
+	var syncMap sync.Map
+	for i := 0; i < keys; i++ {
+		_ = m.Put(i, i)
+		syncMap.Store(i, i)
+	}
+	b.Run("ConcurrentMap read heavy", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i%10 == 0 {
+					_ = m.Put(i&(keys-1), i)
+				} else {
+					_, _ = m.Get(i & (keys - 1))
+				}
+				i++
+			}
+		})
+	})
+	b.Run("sync.Map read heavy", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i%10 == 0 {
+					syncMap.Store(i&(keys-1), i)
+				} else {
+					_, _ = syncMap.Load(i & (keys - 1))
+				}
+				i++
+			}
+		})
+	})
+	b.Run("ConcurrentMap write heavy", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				_ = m.Put(i&(keys-1), i)
+				i++
+			}
+		})
+	})
+	b.Run("sync.Map write heavy", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				syncMap.Store(i&(keys-1), i)
+				i++
+			}
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package mapx
+
+import "sync"
+
+// ConcurrentMultiMap 线程安全的 MultiMap
+type ConcurrentMultiMap[K any, V any] struct {
+	*MultiMap[K, V]
+	lock sync.RWMutex
+}
+
+// NewConcurrentMultiMap 将 m 装饰为线程安全的 MultiMap，装饰之后不能再直接操作 m
+func NewConcurrentMultiMap[K any, V any](m *MultiMap[K, V]) *ConcurrentMultiMap[K, V] {
+	return &ConcurrentMultiMap[K, V]{MultiMap: m}
+}
+
+// Put 往MultiMap添加键值对或向已有key的值追加数据
+func (c *ConcurrentMultiMap[K, V]) Put(key K, value V) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.MultiMap.Put(key, value)
+}
+
+// PutMany 往MultiMap添加键值对或向已有key的值追加数据
+func (c *ConcurrentMultiMap[K, V]) PutMany(key K, values ...V) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.MultiMap.PutMany(key, values...)
+}
+
+// Get 从MultiMap获取已有key的值，若key不存在，返回的bool值为false
+func (c *ConcurrentMultiMap[K, V]) Get(key K) ([]V, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.MultiMap.Get(key)
+}
+
+// Delete 从MultiMap中删除指定的key
+func (c *ConcurrentMultiMap[K, V]) Delete(key K) ([]V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.MultiMap.Delete(key)
+}
+
+// Keys 获取MultiMap所有的key
+func (c *ConcurrentMultiMap[K, V]) Keys() []K {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.MultiMap.Keys()
+}
+
+// Values 获取MultiMap所有的value
+func (c *ConcurrentMultiMap[K, V]) Values() [][]V {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.MultiMap.Values()
+}
+
+// Len 返回MultiMap中key的数量
+func (c *ConcurrentMultiMap[K, V]) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.MultiMap.Len()
+}
+
+// Contains 判断MultiMap中是否存在指定的key
+func (c *ConcurrentMultiMap[K, V]) Contains(key K) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.MultiMap.Contains(key)
+}
+
+// Clear 删除MultiMap中所有的key
+func (c *ConcurrentMultiMap[K, V]) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.MultiMap.Clear()
+}
+
+// Range 遍历MultiMap。遍历的是调用时的快照，fn 在锁外执行，可以修改 MultiMap
+func (c *ConcurrentMultiMap[K, V]) Range(fn func(key K, values []V) bool) {
+	c.lock.RLock()
+	keys, values := make([]K, 0, c.MultiMap.Len()), make([][]V, 0, c.MultiMap.Len())
+	c.MultiMap.Range(func(key K, vals []V) bool {
+		keys = append(keys, key)
+		values = append(values, vals)
+		return true
+	})
+	c.lock.RUnlock()
+	for i := range keys {
+		if !fn(keys[i], values[i]) {
+			return
+		}
+	}
+}
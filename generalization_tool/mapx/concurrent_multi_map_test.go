@@ -0,0 +1,64 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentMultiMap(t *testing.T) {
+	multiMap, err := NewMultiTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	m := NewConcurrentMultiMap(multiMap)
+	assert.NoError(t, m.Put(2, 1))
+	assert.NoError(t, m.PutMany(1, 1, 2))
+	assert.NoError(t, m.Put(2, 2))
+
+	val, ok := m.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 2}, val)
+	assert.Equal(t, []int{1, 2}, m.Keys())
+	assert.Equal(t, [][]int{{1, 2}, {1, 2}}, m.Values())
+
+	assert.Equal(t, 2, m.Len())
+	assert.True(t, m.Contains(1))
+	keys := make([]int, 0)
+	m.Range(func(key int, values []int) bool {
+		// fn 在锁外执行，可以修改 map
+		assert.NoError(t, m.Put(key, 3))
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{1, 2}, keys)
+	assert.Equal(t, [][]int{{1, 2, 3}, {1, 2, 3}}, m.Values())
+
+	val, ok = m.Delete(1)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 2, 3}, val)
+	_, ok = m.Get(1)
+	assert.False(t, ok)
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+}
+
+// TestConcurrentMultiMap_Concurrent 需要配合 -race 运行
+func TestConcurrentMultiMap_Concurrent(t *testing.T) {
+	m := NewConcurrentMultiMap(NewMultiBuiltinMap[int, int](0))
+	const goroutines, values = 8, 100
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < values; i++ {
+				assert.NoError(t, m.Put(g%2, i))
+				_, _ = m.Get(g % 2)
+				_ = m.Values()
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.ElementsMatch(t, []int{0, 1}, m.Keys())
+	val, _ := m.Get(0)
+	assert.Equal(t, goroutines/2*values, len(val))
+}
@@ -0,0 +1,315 @@
+package mapx
+
+import (
+	"generalization_tool"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var _ Map[any, any] = (*ConcurrentSkipList[any, any])(nil)
+
+// ConcurrentSkipList 并发安全的跳表，基于 lazy skip list 算法实现
+// 写操作只锁住待修改节点的前驱节点（细粒度锁），不同位置上的写操作可以并发执行；
+// Get、Contains 和遍历都不需要加锁。
+// 删除分为两步：先给节点打上删除标记（逻辑删除），再把节点从各层链表中摘掉（物理删除）。
+// 遍历是弱一致的：遍历过程中其它 goroutine 的修改不一定能被看到
+type ConcurrentSkipList[K any, V any] struct {
+	head    *concurrentSkipListNode[K, V]
+	compare generalization_tool.Comparator[K]
+	length  atomic.Int64
+}
+
+type concurrentSkipListNode[K any, V any] struct {
+	key   K
+	value atomic.Pointer[V]
+	next  []atomic.Pointer[concurrentSkipListNode[K, V]]
+	lock  sync.Mutex
+	// marked 节点已经被逻辑删除
+	marked atomic.Bool
+	// fullyLinked 节点已经链接到所有层，只有 fullyLinked 的节点才对外可见
+	fullyLinked atomic.Bool
+}
+
+// NewConcurrentSkipList 创建一个空的 ConcurrentSkipList，compare不能为nil
+func NewConcurrentSkipList[K any, V any](compare generalization_tool.Comparator[K]) (*ConcurrentSkipList[K, V], error) {
+	if compare == nil {
+		return nil, errSkipListComparatorIsNull
+	}
+	head := &concurrentSkipListNode[K, V]{
+		next: make([]atomic.Pointer[concurrentSkipListNode[K, V]], skipListMaxLevel),
+	}
+	head.fullyLinked.Store(true)
+	return &ConcurrentSkipList[K, V]{
+		head:    head,
+		compare: compare,
+	}, nil
+}
+
+// Put 插入键值对，若已存在该key，value将会被替换
+func (s *ConcurrentSkipList[K, V]) Put(key K, value V) error {
+	level := concurrentSkipListRandomLevel()
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	for {
+		if found := s.find(key, &preds, &succs); found != -1 {
+			node := succs[found]
+			if !node.marked.Load() {
+				// 节点正在被插入，等待其它 goroutine 链接完成
+				for !node.fullyLinked.Load() {
+					runtime.Gosched()
+				}
+				// Delete 持有节点的锁给节点打删除标记，加锁之后再检查一次，
+				// 避免值写入一个已经被删除的节点
+				node.lock.Lock()
+				if !node.marked.Load() {
+					node.value.Store(&value)
+					node.lock.Unlock()
+					return nil
+				}
+				node.lock.Unlock()
+			}
+			// 节点正在被删除，重试
+			continue
+		}
+
+		highestLocked := -1
+		valid := true
+		var prevPred *concurrentSkipListNode[K, V]
+		for i := 0; valid && i < level; i++ {
+			pred, succ := preds[i], succs[i]
+			if pred != prevPred {
+				pred.lock.Lock()
+				highestLocked = i
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[i].Load() == succ
+		}
+		if !valid {
+			s.unlock(&preds, highestLocked)
+			continue
+		}
+
+		node := &concurrentSkipListNode[K, V]{
+			key:  key,
+			next: make([]atomic.Pointer[concurrentSkipListNode[K, V]], level),
+		}
+		node.value.Store(&value)
+		for i := 0; i < level; i++ {
+			node.next[i].Store(succs[i])
+		}
+		for i := 0; i < level; i++ {
+			preds[i].next[i].Store(node)
+		}
+		node.fullyLinked.Store(true)
+		s.unlock(&preds, highestLocked)
+		s.length.Add(1)
+		return nil
+	}
+}
+
+// Get 返回 key 对应的 value，若未找到则会返回false，不需要加锁
+func (s *ConcurrentSkipList[K, V]) Get(key K) (V, bool) {
+	pred := s.head
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		cur := pred.next[i].Load()
+		for cur != nil && s.compare(cur.key, key) < 0 {
+			pred = cur
+			cur = pred.next[i].Load()
+		}
+		if cur != nil && s.compare(cur.key, key) == 0 {
+			if cur.fullyLinked.Load() && !cur.marked.Load() {
+				return *cur.value.Load(), true
+			}
+			break
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete 删除 key 对应的键值对，并返回被删除的值
+func (s *ConcurrentSkipList[K, V]) Delete(key K) (V, bool) {
+	var preds, succs [skipListMaxLevel]*concurrentSkipListNode[K, V]
+	var victim *concurrentSkipListNode[K, V]
+	isMarked := false
+	for {
+		found := s.find(key, &preds, &succs)
+		if !isMarked {
+			if found == -1 || !s.canDelete(succs[found], found) {
+				var zero V
+				return zero, false
+			}
+			victim = succs[found]
+			victim.lock.Lock()
+			if victim.marked.Load() {
+				// 已经被其它 goroutine 删除
+				victim.lock.Unlock()
+				var zero V
+				return zero, false
+			}
+			victim.marked.Store(true)
+			isMarked = true
+		}
+
+		level := len(victim.next)
+		highestLocked := -1
+		valid := true
+		var prevPred *concurrentSkipListNode[K, V]
+		for i := 0; valid && i < level; i++ {
+			pred := preds[i]
+			if pred != prevPred {
+				pred.lock.Lock()
+				highestLocked = i
+				prevPred = pred
+			}
+			valid = !pred.marked.Load() && pred.next[i].Load() == victim
+		}
+		if !valid {
+			s.unlock(&preds, highestLocked)
+			continue
+		}
+
+		for i := level - 1; i >= 0; i-- {
+			preds[i].next[i].Store(victim.next[i].Load())
+		}
+		victim.lock.Unlock()
+		s.unlock(&preds, highestLocked)
+		s.length.Add(-1)
+		return *victim.value.Load(), true
+	}
+}
+
+// Keys 返回全部的键，按照 key 从小到大的顺序
+func (s *ConcurrentSkipList[K, V]) Keys() []K {
+	keys := make([]K, 0, s.Len())
+	s.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values 返回全部的值，按照 key 从小到大的顺序
+func (s *ConcurrentSkipList[K, V]) Values() []V {
+	values := make([]V, 0, s.Len())
+	s.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Len 返回键值对数量
+func (s *ConcurrentSkipList[K, V]) Len() int {
+	return int(s.length.Load())
+}
+
+// Contains 判断 key 是否存在
+func (s *ConcurrentSkipList[K, V]) Contains(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Clear 逐个删除调用时已存在的键值对，与并发的 Put 之间不保证原子性
+func (s *ConcurrentSkipList[K, V]) Clear() {
+	for _, key := range s.Keys() {
+		s.Delete(key)
+	}
+}
+
+// Range 按照 key 从小到大的顺序遍历，fn 返回 false 时停止遍历
+// 遍历过程中可以修改 ConcurrentSkipList
+func (s *ConcurrentSkipList[K, V]) Range(fn func(key K, value V) bool) {
+	s.ascend(s.head.next[0].Load(), fn)
+}
+
+// AscendFrom 从大于 from（inclusive 为 true 时大于等于）的第一个键开始按顺序遍历，fn 返回 false 时停止遍历
+func (s *ConcurrentSkipList[K, V]) AscendFrom(from K, inclusive bool, fn func(key K, value V) bool) {
+	s.ascend(s.lowNode(from, inclusive), fn)
+}
+
+// AscendRange 按顺序遍历 key 在 from 和 to 之间的键值对，fromInclusive、toInclusive 分别表示是否包含两个端点
+func (s *ConcurrentSkipList[K, V]) AscendRange(from K, fromInclusive bool, to K, toInclusive bool, fn func(key K, value V) bool) {
+	s.ascend(s.lowNode(from, fromInclusive), func(key K, value V) bool {
+		cmp := s.compare(key, to)
+		if cmp > 0 || (cmp == 0 && !toInclusive) {
+			return false
+		}
+		return fn(key, value)
+	})
+}
+
+// ascend 从 node 开始沿着最底层遍历，跳过还没有链接完成或者已经被删除的节点
+func (s *ConcurrentSkipList[K, V]) ascend(node *concurrentSkipListNode[K, V], fn func(key K, value V) bool) {
+	for ; node != nil; node = node.next[0].Load() {
+		if !node.fullyLinked.Load() || node.marked.Load() {
+			continue
+		}
+		if !fn(node.key, *node.value.Load()) {
+			return
+		}
+	}
+}
+
+// lowNode 返回区间下界对应的第一个节点
+func (s *ConcurrentSkipList[K, V]) lowNode(from K, inclusive bool) *concurrentSkipListNode[K, V] {
+	pred := s.head
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		cur := pred.next[i].Load()
+		for cur != nil && s.compare(cur.key, from) < 0 {
+			pred = cur
+			cur = pred.next[i].Load()
+		}
+	}
+	node := pred.next[0].Load()
+	if !inclusive && node != nil && s.compare(node.key, from) == 0 {
+		node = node.next[0].Load()
+	}
+	return node
+}
+
+// find 记录每一层中 key 的前驱和后继节点，返回找到 key 的最高层，没有找到时返回-1
+func (s *ConcurrentSkipList[K, V]) find(key K, preds *[skipListMaxLevel]*concurrentSkipListNode[K, V],
+	succs *[skipListMaxLevel]*concurrentSkipListNode[K, V]) int {
+	found := -1
+	pred := s.head
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		cur := pred.next[i].Load()
+		for cur != nil && s.compare(cur.key, key) < 0 {
+			pred = cur
+			cur = pred.next[i].Load()
+		}
+		if found == -1 && cur != nil && s.compare(cur.key, key) == 0 {
+			found = i
+		}
+		preds[i] = pred
+		succs[i] = cur
+	}
+	return found
+}
+
+// canDelete 只有链接完成、没有被删除并且在最高层被找到的节点才可以删除
+func (s *ConcurrentSkipList[K, V]) canDelete(node *concurrentSkipListNode[K, V], found int) bool {
+	return node.fullyLinked.Load() && len(node.next)-1 == found && !node.marked.Load()
+}
+
+// unlock 释放 0 到 highestLocked 层中被锁住的前驱节点，相邻层的前驱节点可能相同，只释放一次
+func (s *ConcurrentSkipList[K, V]) unlock(preds *[skipListMaxLevel]*concurrentSkipListNode[K, V], highestLocked int) {
+	var prevPred *concurrentSkipListNode[K, V]
+	for i := 0; i <= highestLocked; i++ {
+		if preds[i] != prevPred {
+			preds[i].lock.Unlock()
+			prevPred = preds[i]
+		}
+	}
+}
+
+// concurrentSkipListRandomLevel 使用全局的随机数，全局的随机数是并发安全的
+func concurrentSkipListRandomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Int63()&3 == 0 {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,180 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestNewConcurrentSkipList(t *testing.T) {
+	_, err := NewConcurrentSkipList[int, int](nil)
+	assert.Equal(t, errSkipListComparatorIsNull, err)
+}
+
+func TestConcurrentSkipList(t *testing.T) {
+	s, err := NewConcurrentSkipList[int, int](compare())
+	assert.NoError(t, err)
+	for _, k := range []int{5, 1, 4, 2, 3, 3} {
+		assert.NoError(t, s.Put(k, k*10))
+	}
+	assert.Equal(t, 5, s.Len())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s.Keys())
+	assert.Equal(t, []int{10, 20, 30, 40, 50}, s.Values())
+
+	val, ok := s.Delete(3)
+	assert.True(t, ok)
+	assert.Equal(t, 30, val)
+	_, ok = s.Delete(3)
+	assert.False(t, ok)
+	assert.False(t, s.Contains(3))
+	got, ok := s.Get(4)
+	assert.True(t, ok)
+	assert.Equal(t, 40, got)
+
+	keys := make([]int, 0)
+	s.AscendRange(1, false, 5, true, func(key int, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{2, 4, 5}, keys)
+
+	keys = keys[:0]
+	s.AscendFrom(2, true, func(key int, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal(t, []int{2, 4}, keys)
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, []int{}, s.Keys())
+}
+
+// TestConcurrentSkipList_Concurrent 多个 goroutine 并发读写，需要配合 -race 运行
+func TestConcurrentSkipList_Concurrent(t *testing.T) {
+	s, err := NewConcurrentSkipList[int, int](compare())
+	assert.NoError(t, err)
+	const goroutines, keys = 8, 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(int64(g)))
+			for i := 0; i < 2000; i++ {
+				key := rnd.Intn(keys)
+				switch rnd.Intn(4) {
+				case 0:
+					s.Delete(key)
+				case 1:
+					s.Get(key)
+				case 2:
+					s.Range(func(key int, value int) bool {
+						return true
+					})
+				default:
+					_ = s.Put(key, key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// 每个 goroutine 各自负责不同的 key，最终结果是确定的
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < keys; i += goroutines {
+				_ = s.Put(i, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, keys, s.Len())
+	keySlice := s.Keys()
+	for i := 0; i < keys; i++ {
+		assert.Equal(t, i, keySlice[i])
+		v, ok := s.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+}
+
+// TestConcurrentSkipList_SameKey 多个 goroutine 对同一个 key 并发 Put 和 Delete，需要配合 -race 运行
+// 每次 Put 的值都不同，Delete 返回的值必须是某次 Put 的值，并且同一个值最多只能被删除一次
+func TestConcurrentSkipList_SameKey(t *testing.T) {
+	s, err := NewConcurrentSkipList[int, int](compare())
+	assert.NoError(t, err)
+	const goroutines, rounds = 8, 2000
+	deleted := make([][]int, goroutines)
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				_ = s.Put(0, g*rounds+i)
+				if v, ok := s.Delete(0); ok {
+					deleted[g] = append(deleted[g], v)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	seen := make(map[int]struct{})
+	for _, values := range deleted {
+		for _, v := range values {
+			assert.True(t, v >= 0 && v < goroutines*rounds)
+			_, ok := seen[v]
+			assert.False(t, ok, "value %d deleted twice", v)
+			seen[v] = struct{}{}
+		}
+	}
+	if v, ok := s.Get(0); ok {
+		_, dup := seen[v]
+		assert.False(t, dup)
+		assert.Equal(t, 1, s.Len())
+		assert.Equal(t, []int{0}, s.Keys())
+	} else {
+		assert.Equal(t, 0, s.Len())
+		assert.Equal(t, []int{}, s.Keys())
+	}
+	assert.LessOrEqual(t, len(seen), goroutines*rounds)
+}
+
+// BenchmarkConcurrentSkipList 对比 ConcurrentSkipList 和加锁的 TreeMap 在写多场景下的性能
+func BenchmarkConcurrentSkipList(b *testing.B) {
+	const keys = 1 << 16
+	s, _ := NewConcurrentSkipList[int, int](compare())
+	tm, _ := NewTreeMap[int, int](compare())
+	m := NewConcurrentMapOf[int, int](tm)
+	b.Run("ConcurrentSkipList write heavy", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := rand.Int()
+			for pb.Next() {
+				if i%4 == 3 {
+					s.Delete((i - 1) & (keys - 1))
+				} else {
+					_ = s.Put(i&(keys-1), i)
+				}
+				i = i*31 + 7
+			}
+		})
+	})
+	b.Run("locked TreeMap write heavy", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := rand.Int()
+			for pb.Next() {
+				if i%4 == 3 {
+					m.Delete((i - 1) & (keys - 1))
+				} else {
+					_ = m.Put(i&(keys-1), i)
+				}
+				i = i*31 + 7
+			}
+		})
+	})
+}
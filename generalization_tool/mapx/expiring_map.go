@@ -0,0 +1,262 @@
+package mapx
+
+import (
+	"generalization_tool"
+	"sync"
+	"time"
+)
+
+var _ Map[any, any] = (*ExpiringMap[any, any])(nil)
+
+type expiringEntry[V any] struct {
+	value V
+	// deadline 过期时间，零值表示永不过期
+	deadline time.Time
+}
+
+func (e expiringEntry[V]) expired(now time.Time) bool {
+	return !e.deadline.IsZero() && !now.Before(e.deadline)
+}
+
+// ExpiringMap 支持过期时间的 map，线程安全
+// - 惰性删除：Get 等读操作遇到过期的键值对时会将其删除
+// - 定期删除：调用 StartJanitor 之后，后台 goroutine 会定期清理过期的键值对，Close 时退出
+type ExpiringMap[K any, V any] struct {
+	m        Map[K, expiringEntry[V]]
+	lock     sync.Mutex
+	onExpire func(key K, value V)
+	now      func() time.Time
+
+	janitorOnce sync.Once
+	closeOnce   sync.Once
+	closeCh     chan struct{}
+	janitorDone chan struct{}
+}
+
+// NewExpiringBuiltinMap 创建一个基于内置 map 的 ExpiringMap
+func NewExpiringBuiltinMap[K comparable, V any](size int) *ExpiringMap[K, V] {
+	return newExpiringMap[K, V](NewBuiltinMap[K, expiringEntry[V]](size))
+}
+
+// NewExpiringHashMap 创建一个基于 HashMap 的 ExpiringMap
+func NewExpiringHashMap[K Hashable, V any](size int) *ExpiringMap[K, V] {
+	return newExpiringMap[K, V](NewHashMap[K, expiringEntry[V]](size))
+}
+
+// NewExpiringTreeMap 创建一个基于 TreeMap 的 ExpiringMap，comparator不能为nil
+func NewExpiringTreeMap[K any, V any](comparator generalization_tool.Comparator[K]) (*ExpiringMap[K, V], error) {
+	treeMap, err := NewTreeMap[K, expiringEntry[V]](comparator)
+	if err != nil {
+		return nil, err
+	}
+	return newExpiringMap[K, V](treeMap), nil
+}
+
+func newExpiringMap[K any, V any](m Map[K, expiringEntry[V]]) *ExpiringMap[K, V] {
+	return &ExpiringMap[K, V]{
+		m:           m,
+		now:         time.Now,
+		closeCh:     make(chan struct{}),
+		janitorDone: make(chan struct{}),
+	}
+}
+
+// SetOnExpire 设置键值对因为过期被删除时的回调，回调在锁外执行，可以安全地访问 ExpiringMap
+// 主动 Delete 或者覆盖不会触发回调
+func (e *ExpiringMap[K, V]) SetOnExpire(fn func(key K, value V)) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.onExpire = fn
+}
+
+// Put 放入一个永不过期的键值对
+func (e *ExpiringMap[K, V]) Put(key K, value V) error {
+	return e.PutWithTTL(key, value, 0)
+}
+
+// PutWithTTL 放入一个键值对，ttl 之后过期；ttl <= 0 表示永不过期
+func (e *ExpiringMap[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	entry := expiringEntry[V]{value: value}
+	if ttl > 0 {
+		entry.deadline = e.now().Add(ttl)
+	}
+	return e.m.Put(key, entry)
+}
+
+// Get 返回 key 对应的值，如果已经过期，那么删除该键值对并返回false
+func (e *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	var zero V
+	e.lock.Lock()
+	entry, ok := e.m.Get(key)
+	if !ok {
+		e.lock.Unlock()
+		return zero, false
+	}
+	if !entry.expired(e.now()) {
+		e.lock.Unlock()
+		return entry.value, true
+	}
+	e.m.Delete(key)
+	onExpire := e.onExpire
+	e.lock.Unlock()
+	if onExpire != nil {
+		onExpire(key, entry.value)
+	}
+	return zero, false
+}
+
+// TTL 返回 key 的剩余存活时间。永不过期的键值对返回0和true；不存在或已经过期返回false
+func (e *ExpiringMap[K, V]) TTL(key K) (time.Duration, bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	entry, ok := e.m.Get(key)
+	now := e.now()
+	if !ok || entry.expired(now) {
+		return 0, false
+	}
+	if entry.deadline.IsZero() {
+		return 0, true
+	}
+	return entry.deadline.Sub(now), true
+}
+
+// Delete 删除 key，已经过期的键值对视为不存在
+func (e *ExpiringMap[K, V]) Delete(key K) (V, bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	entry, ok := e.m.Delete(key)
+	if !ok || entry.expired(e.now()) {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Keys 返回所有未过期的键，顺序与底层 map 一致
+func (e *ExpiringMap[K, V]) Keys() []K {
+	keys, _ := e.KeysValues()
+	return keys
+}
+
+// Values 返回所有未过期的值，顺序与底层 map 一致
+func (e *ExpiringMap[K, V]) Values() []V {
+	_, values := e.KeysValues()
+	return values
+}
+
+// KeysValues 返回所有未过期的键值对
+func (e *ExpiringMap[K, V]) KeysValues() ([]K, []V) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	now := e.now()
+	allKeys := e.m.Keys()
+	keys, values := make([]K, 0, len(allKeys)), make([]V, 0, len(allKeys))
+	for _, k := range allKeys {
+		entry, ok := e.m.Get(k)
+		if ok && !entry.expired(now) {
+			keys = append(keys, k)
+			values = append(values, entry.value)
+		}
+	}
+	return keys, values
+}
+
+// Len 返回未过期的键值对数量
+func (e *ExpiringMap[K, V]) Len() int {
+	return len(e.Keys())
+}
+
+// Contains 判断 key 是否存在，已经过期的键值对视为不存在
+func (e *ExpiringMap[K, V]) Contains(key K) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	entry, ok := e.m.Get(key)
+	return ok && !entry.expired(e.now())
+}
+
+// Clear 删除所有键值对，不会触发过期回调
+func (e *ExpiringMap[K, V]) Clear() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.m.Clear()
+}
+
+// Range 遍历所有未过期的键值对，fn 返回 false 时停止遍历
+// 遍历的是调用时的快照，fn 在锁外执行，可以安全地访问 ExpiringMap
+func (e *ExpiringMap[K, V]) Range(fn func(key K, value V) bool) {
+	keys, values := e.KeysValues()
+	for i := range keys {
+		if !fn(keys[i], values[i]) {
+			return
+		}
+	}
+}
+
+// DeleteExpired 立刻清理所有过期的键值对，返回清理的数量
+func (e *ExpiringMap[K, V]) DeleteExpired() int {
+	e.lock.Lock()
+	now := e.now()
+	expiredKeys := make([]K, 0)
+	expiredValues := make([]V, 0)
+	for _, k := range e.m.Keys() {
+		entry, ok := e.m.Get(k)
+		if ok && entry.expired(now) {
+			e.m.Delete(k)
+			expiredKeys = append(expiredKeys, k)
+			expiredValues = append(expiredValues, entry.value)
+		}
+	}
+	onExpire := e.onExpire
+	e.lock.Unlock()
+	if onExpire != nil {
+		for i := range expiredKeys {
+			onExpire(expiredKeys[i], expiredValues[i])
+		}
+	}
+	return len(expiredKeys)
+}
+
+// StartJanitor 启动后台 goroutine，每隔 interval 清理一次过期的键值对
+// interval 必须大于0；多次调用只会启动一个 goroutine；调用 Close 之后再调用不会启动
+func (e *ExpiringMap[K, V]) StartJanitor(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	e.janitorOnce.Do(func() {
+		go e.janitor(interval)
+	})
+}
+
+func (e *ExpiringMap[K, V]) janitor(interval time.Duration) {
+	defer close(e.janitorDone)
+	select {
+	case <-e.closeCh:
+		return
+	default:
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.DeleteExpired()
+		case <-e.closeCh:
+			return
+		}
+	}
+}
+
+// Close 停止后台清理的 goroutine，并等待其退出；可以重复调用
+func (e *ExpiringMap[K, V]) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closeCh)
+		// 如果 janitor 从未启动，那么占用 janitorOnce，保证之后也不会启动
+		e.janitorOnce.Do(func() {
+			close(e.janitorDone)
+		})
+	})
+	<-e.janitorDone
+	return nil
+}
@@ -0,0 +1,217 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewExpiringMap(t *testing.T) {
+	_, err := NewExpiringTreeMap[int, int](nil)
+	assert.Equal(t, errTreeMapComparatorIsNull, err)
+
+	treeMap, err := NewExpiringTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	maps := []*ExpiringMap[int, int]{
+		NewExpiringBuiltinMap[int, int](10),
+		treeMap,
+	}
+	for _, m := range maps {
+		assert.Equal(t, 0, m.Len())
+		assert.Equal(t, []int{}, m.Keys())
+		assert.Equal(t, []int{}, m.Values())
+	}
+	hashMap := NewExpiringHashMap[testData, int](10)
+	assert.Equal(t, []testData{}, hashMap.Keys())
+}
+
+func TestExpiringMap_Get(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ttl      time.Duration
+		elapsed  time.Duration
+		wantVal  int
+		wantOk   bool
+		expired  bool
+		wantKeys []int
+	}{
+		{
+			name:     "never expire",
+			ttl:      0,
+			elapsed:  time.Hour,
+			wantVal:  1,
+			wantOk:   true,
+			wantKeys: []int{1},
+		},
+		{
+			name:     "not expired",
+			ttl:      time.Minute,
+			elapsed:  time.Second,
+			wantVal:  1,
+			wantOk:   true,
+			wantKeys: []int{1},
+		},
+		{
+			name:     "expired",
+			ttl:      time.Minute,
+			elapsed:  time.Minute,
+			expired:  true,
+			wantKeys: []int{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewExpiringTreeMap[int, int](compare())
+			assert.NoError(t, err)
+			clock := newFakeClock()
+			m.now = clock.Now
+			expired := make([]int, 0)
+			m.SetOnExpire(func(key int, value int) {
+				expired = append(expired, key)
+			})
+			assert.NoError(t, m.PutWithTTL(1, 1, tc.ttl))
+			clock.Add(tc.elapsed)
+
+			val, ok := m.Get(1)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.expired, len(expired) == 1)
+			assert.Equal(t, tc.wantKeys, m.Keys())
+			// 过期的键值对已经被惰性删除
+			_, ok = m.m.Get(1)
+			assert.Equal(t, !tc.expired, ok)
+		})
+	}
+}
+
+func TestExpiringMap_TTL(t *testing.T) {
+	m := NewExpiringBuiltinMap[string, int](10)
+	clock := newFakeClock()
+	m.now = clock.Now
+	assert.NoError(t, m.Put("forever", 1))
+	assert.NoError(t, m.PutWithTTL("session", 2, time.Minute))
+
+	ttl, ok := m.TTL("forever")
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), ttl)
+	clock.Add(10 * time.Second)
+	ttl, ok = m.TTL("session")
+	assert.True(t, ok)
+	assert.Equal(t, 50*time.Second, ttl)
+	_, ok = m.TTL("unknown")
+	assert.False(t, ok)
+
+	// 重新 Put 会刷新过期时间
+	assert.NoError(t, m.PutWithTTL("session", 3, time.Minute))
+	clock.Add(55 * time.Second)
+	val, ok := m.Get("session")
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+	clock.Add(5 * time.Second)
+	_, ok = m.TTL("session")
+	assert.False(t, ok)
+}
+
+func TestExpiringMap_Delete(t *testing.T) {
+	m := NewExpiringHashMap[testData, int](10)
+	clock := newFakeClock()
+	m.now = clock.Now
+	assert.NoError(t, m.PutWithTTL(newTestData(1), 1, time.Minute))
+	assert.NoError(t, m.PutWithTTL(newTestData(2), 2, time.Minute))
+
+	val, ok := m.Delete(newTestData(1))
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	_, ok = m.Delete(newTestData(1))
+	assert.False(t, ok)
+
+	clock.Add(time.Minute)
+	_, ok = m.Delete(newTestData(2))
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestExpiringMap_DeleteExpired(t *testing.T) {
+	m, err := NewExpiringTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	clock := newFakeClock()
+	m.now = clock.Now
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, m.PutWithTTL(i, i*10, time.Duration(i)*time.Second))
+	}
+	assert.NoError(t, m.Put(6, 60))
+	expired := make([]int, 0)
+	m.SetOnExpire(func(key int, value int) {
+		assert.Equal(t, key*10, value)
+		// 回调在锁外执行，可以访问 map
+		_, ok := m.Get(key)
+		assert.False(t, ok)
+		expired = append(expired, key)
+	})
+
+	clock.Add(3 * time.Second)
+	assert.Equal(t, []int{4, 5, 6}, m.Keys())
+	assert.Equal(t, []int{40, 50, 60}, m.Values())
+	assert.Equal(t, 3, m.DeleteExpired())
+	assert.Equal(t, []int{1, 2, 3}, expired)
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, 0, m.DeleteExpired())
+}
+
+func TestExpiringMap_Janitor(t *testing.T) {
+	m := NewExpiringBuiltinMap[int, int](10)
+	var mutex sync.Mutex
+	expired := make([]int, 0)
+	m.SetOnExpire(func(key int, value int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		expired = append(expired, key)
+	})
+	assert.NoError(t, m.PutWithTTL(1, 1, time.Millisecond))
+	assert.NoError(t, m.Put(2, 2))
+
+	m.StartJanitor(5 * time.Millisecond)
+	m.StartJanitor(5 * time.Millisecond)
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(expired) == 1
+	}, time.Second, 5*time.Millisecond)
+	_, ok := m.m.Get(1)
+	assert.False(t, ok)
+
+	assert.NoError(t, m.Close())
+	assert.NoError(t, m.Close())
+	// Close 之后不会再启动
+	m.StartJanitor(time.Millisecond)
+	assert.Equal(t, []int{2}, m.Keys())
+}
+
+func TestExpiringMap_CloseWithoutJanitor(t *testing.T) {
+	m := NewExpiringBuiltinMap[int, int](10)
+	assert.NoError(t, m.Close())
+	m.StartJanitor(time.Millisecond)
+	assert.NoError(t, m.Close())
+}
+
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Add(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(d)
+}
@@ -15,9 +15,12 @@ type node[T Hashable, ValType any] struct {
 	next  *node[T, ValType]
 }
 
+var _ Map[Hashable, any] = (*HashMap[Hashable, any])(nil)
+
 type HashMap[T Hashable, ValType any] struct {
 	hashmap  map[uint64]*node[T, ValType]
 	nodePool *syncx.Pool[*node[T, ValType]]
+	length   int
 }
 
 func NewHashMap[T Hashable, ValType any](size int) *HashMap[T, ValType] {
@@ -44,6 +47,7 @@ func (m *HashMap[T, ValType]) Put(key T, value ValType) error {
 		hash = key.Code()
 		newNode := m.newNode(key, value)
 		m.hashmap[hash] = newNode
+		m.length++
 		return nil
 	}
 	pre := root
@@ -59,6 +63,7 @@ func (m *HashMap[T, ValType]) Put(key T, value ValType) error {
 	}
 	newNode := m.newNode(key, value)
 	pre.next = newNode
+	m.length++
 	return nil
 }
 
@@ -104,6 +109,7 @@ func (m *HashMap[T, ValType]) Delete(key T) (ValType, bool) {
 			root.formatting()
 			// 将删除的节点放回节点池中以供复用
 			m.nodePool.Put(root)
+			m.length--
 			return value, true
 		}
 		num++
@@ -145,3 +151,39 @@ func (m *HashMap[T, ValType]) Values() []ValType {
 	}
 	return res
 }
+
+// Len 返回键值对数量
+func (m *HashMap[T, ValType]) Len() int {
+	return m.length
+}
+
+// Contains 判断 key 是否存在
+func (m *HashMap[T, ValType]) Contains(key T) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Clear 删除所有键值对，并将节点放回节点池
+func (m *HashMap[T, ValType]) Clear() {
+	for hash, root := range m.hashmap {
+		for root != nil {
+			next := root.next
+			root.formatting()
+			m.nodePool.Put(root)
+			root = next
+		}
+		delete(m.hashmap, hash)
+	}
+	m.length = 0
+}
+
+// Range 遍历所有键值对，顺序是随机的；fn 返回 false 时停止遍历
+func (m *HashMap[T, ValType]) Range(fn func(key T, value ValType) bool) {
+	for _, n := range m.hashmap {
+		for curNode := n; curNode != nil; curNode = curNode.next {
+			if !fn(curNode.key, curNode.value) {
+				return
+			}
+		}
+	}
+}
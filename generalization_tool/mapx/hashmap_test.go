@@ -6,7 +6,7 @@ import (
 	"testing"
 )
 
-var _ mapi[testData, int] = &HashMap[testData, int]{}
+var _ Map[testData, int] = &HashMap[testData, int]{}
 
 func TestHashMap_Get_PUT(t *testing.T) {
 	cases := []struct {
@@ -0,0 +1,229 @@
+package mapx
+
+import (
+	"generalization_tool"
+	"sort"
+)
+
+// LFU 最不经常使用缓存，Get 和 Put 都是 O(1)
+// 每个访问频率对应一个链表（频率桶），容量已满时淘汰访问频率最低的元素；
+// 频率相同的情况下，淘汰其中最久未被访问的元素
+type LFU[K any, V any] struct {
+	cacheStats
+	m        Map[K, *cacheEntry[K, V]]
+	buckets  map[int]*cacheList[K, V]
+	minFreq  int
+	length   int
+	capacity int
+	onEvict  func(key K, value V)
+}
+
+// NewHashMapLFU 创建一个基于 HashMap 的 LFU，capacity 必须大于0
+func NewHashMapLFU[K Hashable, V any](capacity int) (*LFU[K, V], error) {
+	if capacity <= 0 {
+		return nil, errCacheInvalidCapacity
+	}
+	return newLFU[K, V](NewHashMap[K, *cacheEntry[K, V]](capacity), capacity), nil
+}
+
+// NewTreeMapLFU 创建一个基于 TreeMap 的 LFU，capacity 必须大于0，comparator不能为nil
+func NewTreeMapLFU[K any, V any](capacity int, comparator generalization_tool.Comparator[K]) (*LFU[K, V], error) {
+	if capacity <= 0 {
+		return nil, errCacheInvalidCapacity
+	}
+	treeMap, err := NewTreeMap[K, *cacheEntry[K, V]](comparator)
+	if err != nil {
+		return nil, err
+	}
+	return newLFU[K, V](treeMap, capacity), nil
+}
+
+func newLFU[K any, V any](m Map[K, *cacheEntry[K, V]], capacity int) *LFU[K, V] {
+	return &LFU[K, V]{
+		m:        m,
+		buckets:  make(map[int]*cacheList[K, V]),
+		capacity: capacity,
+	}
+}
+
+// SetOnEvict 设置元素因为容量不足被淘汰时的回调，主动 Delete 不会触发回调
+func (l *LFU[K, V]) SetOnEvict(fn func(key K, value V)) {
+	l.onEvict = fn
+}
+
+// Get 返回 key 对应的值，并增加其访问频率；会被计入命中统计
+func (l *LFU[K, V]) Get(key K) (V, bool) {
+	e, ok := l.m.Get(key)
+	l.record(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	l.touch(e)
+	return e.value, true
+}
+
+// Put 放入键值对。已存在的 key 会更新值并增加访问频率；
+// 新的 key 访问频率为1，放入之前如果容量已满，那么先淘汰访问频率最低的元素
+func (l *LFU[K, V]) Put(key K, value V) error {
+	if e, ok := l.m.Get(key); ok {
+		e.value = value
+		l.touch(e)
+		return nil
+	}
+	if l.Len() >= l.capacity {
+		l.evict()
+	}
+	e := &cacheEntry[K, V]{key: key, value: value, freq: 1}
+	if err := l.m.Put(key, e); err != nil {
+		return err
+	}
+	l.bucket(1).pushBack(e)
+	l.minFreq = 1
+	l.length++
+	return nil
+}
+
+// Peek 返回 key 对应的值，不会改变访问频率
+func (l *LFU[K, V]) Peek(key K) (V, bool) {
+	if e, ok := l.m.Get(key); ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains 判断 key 是否存在，不会改变访问频率
+func (l *LFU[K, V]) Contains(key K) bool {
+	_, ok := l.m.Get(key)
+	return ok
+}
+
+// Delete 删除 key，不会触发淘汰回调
+func (l *LFU[K, V]) Delete(key K) (V, bool) {
+	e, ok := l.m.Delete(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	l.unlink(e)
+	l.length--
+	if _, ok = l.buckets[l.minFreq]; !ok {
+		l.resetMinFreq()
+	}
+	return e.value, true
+}
+
+// Len 返回元素数量
+func (l *LFU[K, V]) Len() int {
+	return l.length
+}
+
+// Cap 返回容量
+func (l *LFU[K, V]) Cap() int {
+	return l.capacity
+}
+
+// Keys 按照访问频率从低到高返回所有的键，频率相同时按照从久到新的顺序
+func (l *LFU[K, V]) Keys() []K {
+	keys := make([]K, 0, l.length)
+	l.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values 返回所有的值，顺序与 Keys 一致
+func (l *LFU[K, V]) Values() []V {
+	values := make([]V, 0, l.length)
+	l.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Range 按照与 Keys 相同的顺序遍历，不会改变访问频率
+func (l *LFU[K, V]) Range(fn func(key K, value V) bool) {
+	freqs := make([]int, 0, len(l.buckets))
+	for freq := range l.buckets {
+		freqs = append(freqs, freq)
+	}
+	sort.Ints(freqs)
+	for _, freq := range freqs {
+		if !l.buckets[freq].rangeEntries(fn) {
+			return
+		}
+	}
+}
+
+// Clear 删除所有元素，不会触发淘汰回调，也不会清空命中统计
+func (l *LFU[K, V]) Clear() {
+	l.m.Clear()
+	l.buckets = make(map[int]*cacheList[K, V])
+	l.minFreq = 0
+	l.length = 0
+}
+
+// Frequency 返回 key 当前的访问频率，key 不存在时返回0
+func (l *LFU[K, V]) Frequency(key K) int {
+	if e, ok := l.m.Get(key); ok {
+		return e.freq
+	}
+	return 0
+}
+
+// touch 将节点从当前频率桶移动到下一个频率桶
+func (l *LFU[K, V]) touch(e *cacheEntry[K, V]) {
+	freq := e.freq
+	l.unlink(e)
+	if freq == l.minFreq && l.buckets[freq] == nil {
+		l.minFreq = freq + 1
+	}
+	e.freq = freq + 1
+	l.bucket(e.freq).pushBack(e)
+}
+
+// unlink 将节点从所在的频率桶中移除，桶为空时删除该桶
+func (l *LFU[K, V]) unlink(e *cacheEntry[K, V]) {
+	b := e.list
+	b.remove(e)
+	if b.length == 0 {
+		delete(l.buckets, e.freq)
+	}
+}
+
+// resetMinFreq 删除元素导致最低频率的桶被删除时，重新计算最低频率
+func (l *LFU[K, V]) resetMinFreq() {
+	l.minFreq = 0
+	for freq := range l.buckets {
+		if l.minFreq == 0 || freq < l.minFreq {
+			l.minFreq = freq
+		}
+	}
+}
+
+func (l *LFU[K, V]) bucket(freq int) *cacheList[K, V] {
+	b, ok := l.buckets[freq]
+	if !ok {
+		b = newCacheList[K, V]()
+		l.buckets[freq] = b
+	}
+	return b
+}
+
+// evict 淘汰访问频率最低的桶中最久未被访问的元素
+func (l *LFU[K, V]) evict() {
+	b, ok := l.buckets[l.minFreq]
+	if !ok {
+		return
+	}
+	e := b.front()
+	l.m.Delete(e.key)
+	l.unlink(e)
+	l.length--
+	if l.onEvict != nil {
+		l.onEvict(e.key, e.value)
+	}
+}
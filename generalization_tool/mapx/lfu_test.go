@@ -0,0 +1,132 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewLFU(t *testing.T) {
+	_, err := NewHashMapLFU[testData, int](0)
+	assert.Equal(t, errCacheInvalidCapacity, err)
+	_, err = NewTreeMapLFU[int, int](0, compare())
+	assert.Equal(t, errCacheInvalidCapacity, err)
+	_, err = NewTreeMapLFU[int, int](1, nil)
+	assert.Equal(t, errTreeMapComparatorIsNull, err)
+
+	lfu, err := NewHashMapLFU[testData, int](2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lfu.Cap())
+	assert.Equal(t, 0, lfu.Len())
+	assert.Equal(t, []testData{}, lfu.Keys())
+}
+
+func TestLFU_Put(t *testing.T) {
+	testCases := []struct {
+		name        string
+		capacity    int
+		puts        []int
+		gets        []int
+		then        []int
+		wantKeys    []int
+		wantEvicted []int
+	}{
+		{
+			name:        "not full",
+			capacity:    3,
+			puts:        []int{1, 2},
+			wantKeys:    []int{1, 2},
+			wantEvicted: []int{},
+		},
+		{
+			name:        "same frequency evict oldest",
+			capacity:    2,
+			puts:        []int{1, 2},
+			then:        []int{3},
+			wantKeys:    []int{2, 3},
+			wantEvicted: []int{1},
+		},
+		{
+			name:        "evict least frequently used",
+			capacity:    3,
+			puts:        []int{1, 2, 3},
+			gets:        []int{1, 1, 2, 3},
+			then:        []int{4, 5},
+			wantKeys:    []int{5, 3, 1},
+			wantEvicted: []int{2, 4},
+		},
+		{
+			name:        "update increases frequency",
+			capacity:    2,
+			puts:        []int{1, 2, 1},
+			then:        []int{3},
+			wantKeys:    []int{3, 1},
+			wantEvicted: []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lfu, err := NewTreeMapLFU[int, int](tc.capacity, compare())
+			assert.NoError(t, err)
+			evicted := make([]int, 0)
+			lfu.SetOnEvict(func(key int, value int) {
+				evicted = append(evicted, key)
+			})
+			for _, k := range tc.puts {
+				assert.NoError(t, lfu.Put(k, k))
+			}
+			for _, k := range tc.gets {
+				_, ok := lfu.Get(k)
+				assert.True(t, ok)
+			}
+			for _, k := range tc.then {
+				assert.NoError(t, lfu.Put(k, k))
+			}
+			assert.Equal(t, tc.wantKeys, lfu.Keys())
+			assert.Equal(t, tc.wantEvicted, evicted)
+		})
+	}
+}
+
+func TestLFU_Get(t *testing.T) {
+	lfu, err := NewHashMapLFU[testData, int](2)
+	assert.NoError(t, err)
+	assert.NoError(t, lfu.Put(newTestData(1), 1))
+
+	val, ok := lfu.Get(newTestData(1))
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 2, lfu.Frequency(newTestData(1)))
+	_, ok = lfu.Get(newTestData(2))
+	assert.False(t, ok)
+	assert.Equal(t, 0, lfu.Frequency(newTestData(2)))
+
+	val, ok = lfu.Peek(newTestData(1))
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	assert.True(t, lfu.Contains(newTestData(1)))
+	assert.Equal(t, 2, lfu.Frequency(newTestData(1)))
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, lfu.Stats())
+}
+
+func TestLFU_Delete(t *testing.T) {
+	lfu, err := NewTreeMapLFU[int, int](2, compare())
+	assert.NoError(t, err)
+	assert.NoError(t, lfu.Put(1, 1))
+	assert.NoError(t, lfu.Put(2, 2))
+	_, _ = lfu.Get(2)
+
+	// 删除最低频率桶中唯一的元素之后，仍然可以正确淘汰
+	val, ok := lfu.Delete(1)
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	_, ok = lfu.Delete(1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, lfu.Len())
+
+	assert.NoError(t, lfu.Put(3, 3))
+	_, _ = lfu.Get(3)
+	_, _ = lfu.Get(3)
+	assert.NoError(t, lfu.Put(4, 4))
+	assert.Equal(t, []int{4, 3}, lfu.Keys())
+	assert.Equal(t, 2, lfu.Len())
+}
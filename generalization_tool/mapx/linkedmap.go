@@ -9,8 +9,10 @@ type linkedKeyValue[K any, V any] struct {
 	next  *linkedKeyValue[K, V]
 }
 
+var _ Map[any, any] = (*LinkedMap[any, any])(nil)
+
 type LinkedMap[K any, V any] struct {
-	m      mapi[K, *linkedKeyValue[K, V]]
+	m      Map[K, *linkedKeyValue[K, V]]
 	head   *linkedKeyValue[K, V]
 	tail   *linkedKeyValue[K, V]
 	length int
@@ -73,6 +75,7 @@ func (l *LinkedMap[K, V]) Get(key K) (V, bool) {
 func (l *LinkedMap[K, V]) Delete(key K) (V, bool) {
 	if lkMap, ok := l.m.Delete(key); ok {
 		lkMap.prev.next, lkMap.next.prev = lkMap.next, lkMap.prev
+		l.length--
 		return lkMap.value, ok
 	}
 	var zero V
@@ -96,3 +99,45 @@ func (l *LinkedMap[K, V]) Values() []V {
 	}
 	return res
 }
+
+func (l *LinkedMap[K, V]) Len() int {
+	return l.length
+}
+
+func (l *LinkedMap[K, V]) Contains(key K) bool {
+	_, ok := l.m.Get(key)
+	return ok
+}
+
+func (l *LinkedMap[K, V]) Clear() {
+	l.m.Clear()
+	l.head.next, l.tail.prev = l.tail, l.head
+	l.length = 0
+}
+
+// Range 按照插入顺序遍历，fn 返回 false 时停止遍历
+func (l *LinkedMap[K, V]) Range(fn func(key K, value V) bool) {
+	for current := l.head.next; current != l.tail; current = current.next {
+		if !fn(current.key, current.value) {
+			return
+		}
+	}
+}
+
+// moveToBack 将节点移动到链表末尾，用于维护访问顺序
+func (l *LinkedMap[K, V]) moveToBack(lkMap *linkedKeyValue[K, V]) {
+	if lkMap.next == l.tail {
+		return
+	}
+	lkMap.prev.next, lkMap.next.prev = lkMap.next, lkMap.prev
+	lkMap.prev, lkMap.next = l.tail.prev, l.tail
+	lkMap.prev.next, lkMap.next.prev = lkMap, lkMap
+}
+
+// front 返回链表的第一个节点，链表为空时返回 nil
+func (l *LinkedMap[K, V]) front() *linkedKeyValue[K, V] {
+	if l.head.next == l.tail {
+		return nil
+	}
+	return l.head.next
+}
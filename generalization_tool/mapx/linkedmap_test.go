@@ -289,6 +289,25 @@ func TestLinkedMap_Delete(t *testing.T) {
 	}
 }
 
+// TestLinkedMap_DeleteLength 删除之后长度减一，删除不存在的 key 长度不变
+func TestLinkedMap_DeleteLength(t *testing.T) {
+	lkMap, err := NewLinkedTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, lkMap.Put(i, i))
+	}
+	assert.Equal(t, 3, lkMap.length)
+
+	_, ok := lkMap.Delete(1)
+	assert.True(t, ok)
+	assert.Equal(t, 2, lkMap.length)
+
+	_, ok = lkMap.Delete(1)
+	assert.False(t, ok)
+	assert.Equal(t, 2, lkMap.length)
+	assert.Equal(t, []int{0, 2}, lkMap.Keys())
+}
+
 func TestLinkedMap_PutAndDelete(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -0,0 +1,131 @@
+package mapx
+
+import "generalization_tool"
+
+// LRU 基于 LinkedMap 实现的最近最少使用缓存
+// LinkedMap 的链表头部是最久未被访问的元素，尾部是最近访问的元素
+// 容量已满时 Put 新的 key 会淘汰最久未被访问的元素
+type LRU[K any, V any] struct {
+	cacheStats
+	linkedMap *LinkedMap[K, V]
+	capacity  int
+	onEvict   func(key K, value V)
+}
+
+// NewHashMapLRU 创建一个基于 HashMap 的 LRU，capacity 必须大于0
+func NewHashMapLRU[K Hashable, V any](capacity int) (*LRU[K, V], error) {
+	if capacity <= 0 {
+		return nil, errCacheInvalidCapacity
+	}
+	return &LRU[K, V]{
+		linkedMap: NewLinkedHashMap[K, V](capacity),
+		capacity:  capacity,
+	}, nil
+}
+
+// NewTreeMapLRU 创建一个基于 TreeMap 的 LRU，capacity 必须大于0，comparator不能为nil
+func NewTreeMapLRU[K any, V any](capacity int, comparator generalization_tool.Comparator[K]) (*LRU[K, V], error) {
+	if capacity <= 0 {
+		return nil, errCacheInvalidCapacity
+	}
+	linkedMap, err := NewLinkedTreeMap[K, V](comparator)
+	if err != nil {
+		return nil, err
+	}
+	return &LRU[K, V]{
+		linkedMap: linkedMap,
+		capacity:  capacity,
+	}, nil
+}
+
+// SetOnEvict 设置元素因为容量不足被淘汰时的回调，主动 Delete 不会触发回调
+func (l *LRU[K, V]) SetOnEvict(fn func(key K, value V)) {
+	l.onEvict = fn
+}
+
+// Put 放入键值对并将其标记为最近访问。如果放入新的 key 之后超出容量，那么淘汰最久未被访问的元素
+func (l *LRU[K, V]) Put(key K, value V) error {
+	if lkMap, ok := l.linkedMap.m.Get(key); ok {
+		lkMap.value = value
+		l.linkedMap.moveToBack(lkMap)
+		return nil
+	}
+	if err := l.linkedMap.Put(key, value); err != nil {
+		return err
+	}
+	if l.linkedMap.length > l.capacity {
+		l.evict()
+	}
+	return nil
+}
+
+// Get 返回 key 对应的值，并将其标记为最近访问；会被计入命中统计
+func (l *LRU[K, V]) Get(key K) (V, bool) {
+	lkMap, ok := l.linkedMap.m.Get(key)
+	l.record(ok)
+	if ok {
+		l.linkedMap.moveToBack(lkMap)
+		return lkMap.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek 返回 key 对应的值，不会改变访问顺序
+func (l *LRU[K, V]) Peek(key K) (V, bool) {
+	return l.linkedMap.Get(key)
+}
+
+// Contains 判断 key 是否存在，不会改变访问顺序
+func (l *LRU[K, V]) Contains(key K) bool {
+	_, ok := l.linkedMap.m.Get(key)
+	return ok
+}
+
+// Delete 删除 key，不会触发淘汰回调
+func (l *LRU[K, V]) Delete(key K) (V, bool) {
+	return l.linkedMap.Delete(key)
+}
+
+// Len 返回元素数量
+func (l *LRU[K, V]) Len() int {
+	return l.linkedMap.length
+}
+
+// Cap 返回容量
+func (l *LRU[K, V]) Cap() int {
+	return l.capacity
+}
+
+// Keys 按照从最久未被访问到最近访问的顺序返回所有的键
+func (l *LRU[K, V]) Keys() []K {
+	return l.linkedMap.Keys()
+}
+
+// Values 按照从最久未被访问到最近访问的顺序返回所有的值
+func (l *LRU[K, V]) Values() []V {
+	return l.linkedMap.Values()
+}
+
+// Clear 删除所有元素，不会触发淘汰回调，也不会清空命中统计
+func (l *LRU[K, V]) Clear() {
+	l.linkedMap.Clear()
+}
+
+// Range 按照从最久未被访问到最近访问的顺序遍历，不会改变访问顺序
+func (l *LRU[K, V]) Range(fn func(key K, value V) bool) {
+	l.linkedMap.Range(fn)
+}
+
+// evict 淘汰最久未被访问的元素
+func (l *LRU[K, V]) evict() {
+	oldest := l.linkedMap.front()
+	if oldest == nil {
+		return
+	}
+	key, value := oldest.key, oldest.value
+	l.linkedMap.Delete(key)
+	if l.onEvict != nil {
+		l.onEvict(key, value)
+	}
+}
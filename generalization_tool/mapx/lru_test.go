@@ -0,0 +1,128 @@
+package mapx
+
+import (
+	"generalization_tool"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewLRU(t *testing.T) {
+	_, err := NewHashMapLRU[testData, int](0)
+	assert.Equal(t, errCacheInvalidCapacity, err)
+	_, err = NewTreeMapLRU[int, int](-1, compare())
+	assert.Equal(t, errCacheInvalidCapacity, err)
+	_, err = NewTreeMapLRU[int, int](1, nil)
+	assert.Equal(t, errTreeMapComparatorIsNull, err)
+
+	hashLRU, err := NewHashMapLRU[testData, int](2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, hashLRU.Cap())
+	assert.Equal(t, 0, hashLRU.Len())
+	assert.Equal(t, []testData{}, hashLRU.Keys())
+}
+
+func TestLRU_Put(t *testing.T) {
+	testCases := []struct {
+		name        string
+		capacity    int
+		keys        []int
+		wantKeys    []int
+		wantEvicted []int
+	}{
+		{
+			name:        "not full",
+			capacity:    3,
+			keys:        []int{1, 2},
+			wantKeys:    []int{1, 2},
+			wantEvicted: []int{},
+		},
+		{
+			name:        "evict oldest",
+			capacity:    3,
+			keys:        []int{1, 2, 3, 4, 5},
+			wantKeys:    []int{3, 4, 5},
+			wantEvicted: []int{1, 2},
+		},
+		{
+			name:        "update refreshes recency",
+			capacity:    3,
+			keys:        []int{1, 2, 3, 1, 4},
+			wantKeys:    []int{3, 1, 4},
+			wantEvicted: []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lru, err := NewTreeMapLRU[int, int](tc.capacity, compare())
+			assert.NoError(t, err)
+			evicted := make([]int, 0)
+			lru.SetOnEvict(func(key int, value int) {
+				assert.Equal(t, key*10, value)
+				evicted = append(evicted, key)
+			})
+			for _, k := range tc.keys {
+				assert.NoError(t, lru.Put(k, k*10))
+			}
+			assert.Equal(t, tc.wantKeys, lru.Keys())
+			assert.Equal(t, tc.wantEvicted, evicted)
+			assert.Equal(t, len(tc.wantKeys), lru.Len())
+		})
+	}
+}
+
+func TestLRU_Get(t *testing.T) {
+	lru, err := NewHashMapLRU[testData, int](3)
+	assert.NoError(t, err)
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, lru.Put(newTestData(i), i))
+	}
+	val, ok := lru.Get(newTestData(1))
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	_, ok = lru.Get(newTestData(4))
+	assert.False(t, ok)
+
+	// 1 被访问过，淘汰的应该是 2
+	assert.NoError(t, lru.Put(newTestData(4), 4))
+	assert.Equal(t, []testData{newTestData(3), newTestData(1), newTestData(4)}, lru.Keys())
+	assert.Equal(t, []int{3, 1, 4}, lru.Values())
+}
+
+func TestLRU_PeekContains(t *testing.T) {
+	lru, err := NewTreeMapLRU[int, int](2, generalization_tool.ComparatorRealNumber[int])
+	assert.NoError(t, err)
+	assert.NoError(t, lru.Put(1, 1))
+	assert.NoError(t, lru.Put(2, 2))
+
+	val, ok := lru.Peek(1)
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	assert.True(t, lru.Contains(1))
+	assert.False(t, lru.Contains(3))
+
+	// Peek 和 Contains 不改变访问顺序，淘汰的仍然是 1
+	assert.NoError(t, lru.Put(3, 3))
+	assert.Equal(t, []int{2, 3}, lru.Keys())
+}
+
+func TestLRU_Delete(t *testing.T) {
+	lru, err := NewTreeMapLRU[int, int](2, compare())
+	assert.NoError(t, err)
+	evicted := 0
+	lru.SetOnEvict(func(key int, value int) {
+		evicted++
+	})
+	assert.NoError(t, lru.Put(1, 1))
+	assert.NoError(t, lru.Put(2, 2))
+
+	val, ok := lru.Delete(1)
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	_, ok = lru.Delete(1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, lru.Len())
+
+	assert.NoError(t, lru.Put(3, 3))
+	assert.Equal(t, []int{2, 3}, lru.Keys())
+	assert.Equal(t, 0, evicted)
+}
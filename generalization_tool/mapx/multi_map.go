@@ -3,8 +3,9 @@ package mapx
 import "generalization_tool"
 
 // MultiMap 多映射的map，可以将一个键映射到多个值上
+// Put 追加的是单个值，因此 MultiMap 没有实现 Map 接口
 type MultiMap[K any, V any] struct {
-	m mapi[K, []V]
+	m Map[K, []V]
 }
 
 // NewMultiTreeMap 创建一个基于TreeMap的MultiMap。comparator不能为nil
@@ -20,7 +21,7 @@ func NewMultiTreeMap[K any, V any](comparator generalization_tool.Comparator[K])
 
 // NewMultiHashMap 创建一个基于HashMap的MultiMap。comparator不能为nil
 func NewMultiHashMap[K Hashable, V any](size int) *MultiMap[K, V] {
-	var m mapi[K, []V] = NewHashMap[K, []V](size)
+	var m Map[K, []V] = NewHashMap[K, []V](size)
 	return &MultiMap[K, V]{
 		m: m,
 	}
@@ -28,7 +29,7 @@ func NewMultiHashMap[K Hashable, V any](size int) *MultiMap[K, V] {
 
 // NewMultiBuiltinMap 创建一个基于HashMap的MultiMap。comparator不能为nil
 func NewMultiBuiltinMap[K comparable, V any](size int) *MultiMap[K, V] {
-	var m mapi[K, []V] = newBuiltinMap[K, []V](size)
+	var m Map[K, []V] = NewBuiltinMap[K, []V](size)
 	return &MultiMap[K, V]{
 		m: m,
 	}
@@ -73,3 +74,25 @@ func (m *MultiMap[K, V]) Values() [][]V {
 	}
 	return copiedValues
 }
+
+// Len 返回MultiMap中key的数量
+func (m *MultiMap[K, V]) Len() int {
+	return m.m.Len()
+}
+
+// Contains 判断MultiMap中是否存在指定的key
+func (m *MultiMap[K, V]) Contains(key K) bool {
+	return m.m.Contains(key)
+}
+
+// Clear 删除MultiMap中所有的key
+func (m *MultiMap[K, V]) Clear() {
+	m.m.Clear()
+}
+
+// Range 遍历MultiMap，顺序与Keys一致，fn 返回 false 时停止遍历
+func (m *MultiMap[K, V]) Range(fn func(key K, values []V) bool) {
+	m.m.Range(func(key K, values []V) bool {
+		return fn(key, append([]V{}, values...))
+	})
+}
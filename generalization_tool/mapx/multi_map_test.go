@@ -558,3 +558,27 @@ func getMultiTreeMap() *MultiMap[int, int] {
 func getMultiHashMap() *MultiMap[testData, int] {
 	return NewMultiHashMap[testData, int](10)
 }
+
+func TestMultiMap_Range(t *testing.T) {
+	m := getMultiTreeMap()
+	assert.NoError(t, m.PutMany(2, 20, 21))
+	assert.NoError(t, m.Put(1, 10))
+	assert.NoError(t, m.Put(3, 30))
+	assert.Equal(t, 3, m.Len())
+	assert.True(t, m.Contains(2))
+	assert.False(t, m.Contains(4))
+
+	keys := make([]int, 0)
+	m.Range(func(key int, values []int) bool {
+		keys = append(keys, key)
+		// 修改返回的切片不会影响 MultiMap
+		values[0] = 0
+		return key < 2
+	})
+	assert.Equal(t, []int{1, 2}, keys)
+	assert.Equal(t, [][]int{{10}, {20, 21}, {30}}, m.Values())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, []int{}, m.Keys())
+}
@@ -1,5 +0,0 @@
-package mapx
-
-type MultiMap[K any, V any] struct {
-	m mapi[K, []V]
-}
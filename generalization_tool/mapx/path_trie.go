@@ -0,0 +1,272 @@
+package mapx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errPathTrieDuplicatePattern = errors.New("PathTrie：路由已存在")
+	errPathTrieParamConflict    = errors.New("PathTrie：同一位置的参数名冲突")
+	errPathTrieWildcardNotLast  = errors.New("PathTrie：通配符只能是最后一段")
+	errPathTrieEmptyName        = errors.New("PathTrie：参数名不能为空")
+	errPathTrieDuplicateName    = errors.New("PathTrie：同一个路由中的参数名不能重复")
+)
+
+// PathTrie 按照 "/" 分段的路由前缀树，不是并发安全的
+// 路由中的每一段可以是：
+//   - 静态段，例如 "users"，只匹配完全相同的一段
+//   - 参数段，例如 ":id"，匹配任意非空的一段，匹配到的内容以 id 为名返回
+//   - 通配符段，例如 "*rest"，只能是最后一段，匹配剩下的所有段（至少一段），以 rest 为名返回
+//
+// 匹配时同一位置的优先级为：静态段 > 参数段 > 通配符段，优先级高的分支匹配失败时会回退尝试下一个分支。
+// 路由首尾的 "/" 会被忽略，"/users/" 和 "users" 是同一个路由
+type PathTrie[V any] struct {
+	root   *pathNode[V]
+	length int
+}
+
+type pathNode[V any] struct {
+	children map[string]*pathNode[V]
+
+	param     *pathNode[V]
+	paramName string
+
+	wildcard     *pathNode[V]
+	wildcardName string
+
+	value    V
+	hasValue bool
+}
+
+// NewPathTrie 创建一个空的 PathTrie
+func NewPathTrie[V any]() *PathTrie[V] {
+	return &PathTrie[V]{
+		root: &pathNode[V]{},
+	}
+}
+
+// Put 注册路由 pattern，出现以下情况时返回错误，并且不会修改 PathTrie：
+// 路由已经注册过；同一位置已经存在名字不同的参数段或者通配符段；通配符不是最后一段；参数名为空；
+// 同一个路由中出现重复的参数名或者通配符名
+func (p *PathTrie[V]) Put(pattern string, value V) error {
+	segments := splitPath(pattern)
+	if err := p.check(pattern, segments); err != nil {
+		return err
+	}
+	n := p.root
+	for _, seg := range segments {
+		switch segmentKind(seg) {
+		case ':':
+			if n.param == nil {
+				n.param, n.paramName = &pathNode[V]{}, seg[1:]
+			}
+			n = n.param
+		case '*':
+			if n.wildcard == nil {
+				n.wildcard, n.wildcardName = &pathNode[V]{}, seg[1:]
+			}
+			n = n.wildcard
+		default:
+			c, ok := n.children[seg]
+			if !ok {
+				if n.children == nil {
+					n.children = make(map[string]*pathNode[V])
+				}
+				c = &pathNode[V]{}
+				n.children[seg] = c
+			}
+			n = c
+		}
+	}
+	n.value, n.hasValue = value, true
+	p.length++
+	return nil
+}
+
+// Match 返回和 path 匹配的路由对应的值，以及从 path 中提取出来的参数，没有匹配的路由时返回false
+func (p *PathTrie[V]) Match(path string) (V, map[string]string, bool) {
+	params := make(map[string]string)
+	if n := p.match(p.root, splitPath(path), params); n != nil {
+		return n.value, params, true
+	}
+	var zero V
+	return zero, nil, false
+}
+
+// Get 返回已注册的路由 pattern 对应的值，pattern 按照字面比较，不会进行参数匹配
+func (p *PathTrie[V]) Get(pattern string) (V, bool) {
+	n := p.root
+	for _, seg := range splitPath(pattern) {
+		if n = n.next(seg); n == nil {
+			var zero V
+			return zero, false
+		}
+	}
+	return n.value, n.hasValue
+}
+
+// Delete 删除已注册的路由 pattern，并返回对应的值
+// 删除之后没有用到的节点也会被删除，之后可以在同一位置注册使用其它参数名的路由
+func (p *PathTrie[V]) Delete(pattern string) (V, bool) {
+	value, ok := p.delete(p.root, splitPath(pattern))
+	if ok {
+		p.length--
+	}
+	return value, ok
+}
+
+// Len 返回已注册的路由数量
+func (p *PathTrie[V]) Len() int {
+	return p.length
+}
+
+// check 检查 pattern 是否合法，以及是否和已经注册的路由冲突
+func (p *PathTrie[V]) check(pattern string, segments []string) error {
+	n := p.root
+	names := make(map[string]struct{}, len(segments))
+	for i, seg := range segments {
+		kind := segmentKind(seg)
+		if kind == 0 {
+			continue
+		}
+		if len(seg) == 1 {
+			return fmt.Errorf("%w：%s", errPathTrieEmptyName, pattern)
+		}
+		if kind == '*' && i != len(segments)-1 {
+			return fmt.Errorf("%w：%s", errPathTrieWildcardNotLast, pattern)
+		}
+		if _, ok := names[seg[1:]]; ok {
+			return fmt.Errorf("%w：%s", errPathTrieDuplicateName, pattern)
+		}
+		names[seg[1:]] = struct{}{}
+	}
+	for _, seg := range segments {
+		if n == nil {
+			// 剩下的部分都是新的节点，不会冲突
+			return nil
+		}
+		switch segmentKind(seg) {
+		case ':':
+			if n.param != nil && n.paramName != seg[1:] {
+				return fmt.Errorf("%w：%s 和 :%s", errPathTrieParamConflict, pattern, n.paramName)
+			}
+			n = n.param
+		case '*':
+			if n.wildcard != nil && n.wildcardName != seg[1:] {
+				return fmt.Errorf("%w：%s 和 *%s", errPathTrieParamConflict, pattern, n.wildcardName)
+			}
+			n = n.wildcard
+		default:
+			n = n.children[seg]
+		}
+	}
+	if n != nil && n.hasValue {
+		return fmt.Errorf("%w：%s", errPathTrieDuplicatePattern, pattern)
+	}
+	return nil
+}
+
+// match 按照优先级依次尝试静态段、参数段和通配符段，返回匹配到的节点，没有匹配时返回 nil
+func (p *PathTrie[V]) match(n *pathNode[V], segments []string, params map[string]string) *pathNode[V] {
+	if len(segments) == 0 {
+		if n.hasValue {
+			return n
+		}
+		return nil
+	}
+	seg := segments[0]
+	if c, ok := n.children[seg]; ok {
+		if res := p.match(c, segments[1:], params); res != nil {
+			return res
+		}
+	}
+	if n.param != nil && seg != "" {
+		// 回退时恢复原来的参数，而不是直接删除
+		prev, existed := params[n.paramName]
+		params[n.paramName] = seg
+		if res := p.match(n.param, segments[1:], params); res != nil {
+			return res
+		}
+		if existed {
+			params[n.paramName] = prev
+		} else {
+			delete(params, n.paramName)
+		}
+	}
+	if n.wildcard != nil && n.wildcard.hasValue {
+		params[n.wildcardName] = strings.Join(segments, "/")
+		return n.wildcard
+	}
+	return nil
+}
+
+// delete 从以 n 为根的子树中删除 segments 对应的路由，并删除不再使用的子节点
+func (p *PathTrie[V]) delete(n *pathNode[V], segments []string) (V, bool) {
+	var zero V
+	if len(segments) == 0 {
+		if !n.hasValue {
+			return zero, false
+		}
+		value := n.value
+		n.value, n.hasValue = zero, false
+		return value, true
+	}
+	seg := segments[0]
+	c := n.next(seg)
+	if c == nil {
+		return zero, false
+	}
+	value, ok := p.delete(c, segments[1:])
+	if ok && c.isEmpty() {
+		switch segmentKind(seg) {
+		case ':':
+			n.param, n.paramName = nil, ""
+		case '*':
+			n.wildcard, n.wildcardName = nil, ""
+		default:
+			delete(n.children, seg)
+		}
+	}
+	return value, ok
+}
+
+// next 按照字面返回 seg 对应的子节点，参数段和通配符段的名字必须相同
+func (n *pathNode[V]) next(seg string) *pathNode[V] {
+	switch segmentKind(seg) {
+	case ':':
+		if n.paramName == seg[1:] {
+			return n.param
+		}
+		return nil
+	case '*':
+		if n.wildcardName == seg[1:] {
+			return n.wildcard
+		}
+		return nil
+	default:
+		return n.children[seg]
+	}
+}
+
+func (n *pathNode[V]) isEmpty() bool {
+	return !n.hasValue && len(n.children) == 0 && n.param == nil && n.wildcard == nil
+}
+
+// segmentKind 返回参数段的 ':'、通配符段的 '*'，静态段返回0
+func segmentKind(seg string) byte {
+	if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+		return seg[0]
+	}
+	return 0
+}
+
+// splitPath 去掉首尾的 "/" 之后按照 "/" 分段，空路径返回 nil
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
@@ -0,0 +1,183 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPathTrie_Put(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patterns []string
+		pattern  string
+		wantErr  error
+	}{
+		{name: "root", pattern: "/"},
+		{name: "static", patterns: []string{"/users/new"}, pattern: "/users/list"},
+		{name: "param and static", patterns: []string{"/users/new"}, pattern: "/users/:id"},
+		{name: "same param name", patterns: []string{"/users/:id"}, pattern: "/users/:id/orders"},
+		{name: "param and wildcard", patterns: []string{"/users/:id"}, pattern: "/users/*rest"},
+		{name: "duplicate", patterns: []string{"/users/:id"}, pattern: "users/:id/", wantErr: errPathTrieDuplicatePattern},
+		{name: "param conflict", patterns: []string{"/users/:id"}, pattern: "/users/:name/orders", wantErr: errPathTrieParamConflict},
+		{name: "wildcard conflict", patterns: []string{"/files/*path"}, pattern: "/files/*rest", wantErr: errPathTrieParamConflict},
+		{name: "wildcard not last", pattern: "/files/*path/raw", wantErr: errPathTrieWildcardNotLast},
+		{name: "empty param name", pattern: "/users/:", wantErr: errPathTrieEmptyName},
+		{name: "empty wildcard name", pattern: "/files/*", wantErr: errPathTrieEmptyName},
+		{name: "duplicate param name", pattern: "/:x/:x/b", wantErr: errPathTrieDuplicateName},
+		{name: "duplicate wildcard name", pattern: "/:rest/*rest", wantErr: errPathTrieDuplicateName},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPathTrie[string]()
+			for _, pattern := range tc.patterns {
+				assert.NoError(t, p.Put(pattern, pattern))
+			}
+			err := p.Put(tc.pattern, tc.pattern)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tc.patterns)+1, p.Len())
+				return
+			}
+			// 冲突时不会修改 PathTrie
+			assert.Equal(t, len(tc.patterns), p.Len())
+			if value, ok := p.Get(tc.pattern); ok {
+				assert.NotEqual(t, tc.pattern, value)
+			}
+		})
+	}
+}
+
+func TestPathTrie_Match(t *testing.T) {
+	p := NewPathTrie[string]()
+	for _, pattern := range []string{
+		"/",
+		"/users/new",
+		"/users/:id",
+		"/users/:id/orders/*rest",
+		"/users/:id/profile",
+		"/users/new/profile/edit",
+		"/files/*path",
+		"/a//b",
+	} {
+		assert.NoError(t, p.Put(pattern, pattern))
+	}
+	testCases := []struct {
+		name       string
+		path       string
+		wantValue  string
+		wantParams map[string]string
+		wantOk     bool
+	}{
+		{name: "root", path: "/", wantValue: "/", wantParams: map[string]string{}, wantOk: true},
+		{name: "static before param", path: "/users/new", wantValue: "/users/new", wantParams: map[string]string{}, wantOk: true},
+		{name: "param", path: "/users/42", wantValue: "/users/:id", wantParams: map[string]string{"id": "42"}, wantOk: true},
+		{
+			name:       "fallback from static to param",
+			path:       "/users/new/profile",
+			wantValue:  "/users/:id/profile",
+			wantParams: map[string]string{"id": "new"},
+			wantOk:     true,
+		},
+		{
+			name:       "wildcard",
+			path:       "/users/42/orders/2023/10",
+			wantValue:  "/users/:id/orders/*rest",
+			wantParams: map[string]string{"id": "42", "rest": "2023/10"},
+			wantOk:     true,
+		},
+		{name: "wildcard needs one segment", path: "/users/42/orders"},
+		{name: "files", path: "/files/css/app.css", wantValue: "/files/*path", wantParams: map[string]string{"path": "css/app.css"}, wantOk: true},
+		{name: "trailing slash", path: "/users/42/", wantValue: "/users/:id", wantParams: map[string]string{"id": "42"}, wantOk: true},
+		{name: "empty segment", path: "/a//b", wantValue: "/a//b", wantParams: map[string]string{}, wantOk: true},
+		{name: "param does not match empty segment", path: "/users//profile"},
+		{name: "no match", path: "/orders"},
+		{name: "too long", path: "/users/42/profile/edit"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, params, ok := p.Match(tc.path)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantValue, value)
+			assert.Equal(t, tc.wantParams, params)
+		})
+	}
+}
+
+func TestPathTrie_Precedence(t *testing.T) {
+	p := NewPathTrie[string]()
+	assert.NoError(t, p.Put("/:a/x", "param"))
+	assert.NoError(t, p.Put("/*rest", "wildcard"))
+	assert.NoError(t, p.Put("/s/y", "static"))
+
+	testCases := []struct {
+		path      string
+		wantValue string
+	}{
+		{path: "/s/y", wantValue: "static"},
+		{path: "/s/x", wantValue: "param"},
+		{path: "/s/z", wantValue: "wildcard"},
+		{path: "/s", wantValue: "wildcard"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			value, _, ok := p.Match(tc.path)
+			assert.True(t, ok)
+			assert.Equal(t, tc.wantValue, value)
+		})
+	}
+}
+
+// TestPathTrie_Backtrack 参数分支匹配失败回退时，不能丢失外层已经提取的参数
+func TestPathTrie_Backtrack(t *testing.T) {
+	p := NewPathTrie[string]()
+	assert.NoError(t, p.Put("/:x/:y/b", "param"))
+	assert.NoError(t, p.Put("/:x/*rest", "wildcard"))
+
+	value, params, ok := p.Match("/1/2/z")
+	assert.True(t, ok)
+	assert.Equal(t, "wildcard", value)
+	assert.Equal(t, map[string]string{"x": "1", "rest": "2/z"}, params)
+
+	value, params, ok = p.Match("/1/2/b")
+	assert.True(t, ok)
+	assert.Equal(t, "param", value)
+	assert.Equal(t, map[string]string{"x": "1", "y": "2"}, params)
+}
+
+func TestPathTrie_Delete(t *testing.T) {
+	p := NewPathTrie[int]()
+	assert.NoError(t, p.Put("/users/:id", 1))
+	assert.NoError(t, p.Put("/users/:id/orders", 2))
+
+	_, ok := p.Delete("/users/:name")
+	assert.False(t, ok)
+	_, ok = p.Delete("/users")
+	assert.False(t, ok)
+
+	v, ok := p.Delete("/users/:id/orders")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 1, p.Len())
+	_, _, ok = p.Match("/users/1/orders")
+	assert.False(t, ok)
+
+	v, ok = p.Delete("/users/:id")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 0, p.Len())
+	assert.True(t, p.root.isEmpty())
+
+	// 删除之后可以在同一位置使用其它参数名
+	assert.NoError(t, p.Put("/users/:name", 3))
+	v, params, ok := p.Match("/users/tom")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, map[string]string{"name": "tom"}, params)
+
+	v, ok = p.Get("/users/:name")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	_, ok = p.Get("/users/tom")
+	assert.False(t, ok)
+}
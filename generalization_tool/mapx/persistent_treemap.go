@@ -0,0 +1,339 @@
+package mapx
+
+import "generalization_tool"
+
+// PersistentTreeMap 持久化（不可变）的有序 map，基于路径复制的 AVL 树实现
+// Put 和 Delete 不会修改当前的 map，而是返回一个新的版本，新版本与旧版本共享没有变化的节点，
+// 每次修改只复制从根节点到目标节点路径上的 O(log n) 个节点。
+// 任何一个版本都不会再被修改，因此可以在多个 goroutine 之间传递并且无锁地并发读取
+type PersistentTreeMap[K any, V any] struct {
+	root    *persistentNode[K, V]
+	compare generalization_tool.Comparator[K]
+	size    int
+}
+
+type persistentNode[K any, V any] struct {
+	key    K
+	value  V
+	height int
+	left   *persistentNode[K, V]
+	right  *persistentNode[K, V]
+}
+
+// NewPersistentTreeMap 创建一个空的 PersistentTreeMap，compare不能为nil
+func NewPersistentTreeMap[K any, V any](compare generalization_tool.Comparator[K]) (*PersistentTreeMap[K, V], error) {
+	if compare == nil {
+		return nil, errTreeMapComparatorIsNull
+	}
+	return &PersistentTreeMap[K, V]{compare: compare}, nil
+}
+
+// Put 返回放入键值对之后的新版本，若已存在该key，新版本中的value将会被替换；当前版本不会改变
+func (p *PersistentTreeMap[K, V]) Put(key K, value V) *PersistentTreeMap[K, V] {
+	root, added := p.put(p.root, key, value)
+	size := p.size
+	if added {
+		size++
+	}
+	return p.withRoot(root, size)
+}
+
+// Get 返回 key 对应的 value，若未找到则会返回false
+func (p *PersistentTreeMap[K, V]) Get(key K) (V, bool) {
+	n := p.root
+	for n != nil {
+		cmp := p.compare(key, n.key)
+		if cmp < 0 {
+			n = n.left
+		} else if cmp > 0 {
+			n = n.right
+		} else {
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete 返回删除 key 之后的新版本，以及被删除的值；key 不存在时返回当前版本和false
+func (p *PersistentTreeMap[K, V]) Delete(key K) (*PersistentTreeMap[K, V], V, bool) {
+	root, value, ok := p.delete(p.root, key)
+	if !ok {
+		return p, value, false
+	}
+	return p.withRoot(root, p.size-1), value, true
+}
+
+// Contains 判断 key 是否存在
+func (p *PersistentTreeMap[K, V]) Contains(key K) bool {
+	_, ok := p.Get(key)
+	return ok
+}
+
+// Len 返回键值对数量
+func (p *PersistentTreeMap[K, V]) Len() int {
+	return p.size
+}
+
+// Keys 返回全部的键（中序遍历）
+func (p *PersistentTreeMap[K, V]) Keys() []K {
+	keys := make([]K, 0, p.size)
+	p.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values 返回全部的值（中序遍历）
+func (p *PersistentTreeMap[K, V]) Values() []V {
+	values := make([]V, 0, p.size)
+	p.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Range 按照 key 从小到大的顺序遍历，fn 返回 false 时停止遍历
+func (p *PersistentTreeMap[K, V]) Range(fn func(key K, value V) bool) {
+	stack := make([]*persistentNode[K, V], 0, p.root.getHeight())
+	cur := p.root
+	for cur != nil || len(stack) > 0 {
+		for cur != nil {
+			stack = append(stack, cur)
+			cur = cur.left
+		}
+		cur = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !fn(cur.key, cur.value) {
+			return
+		}
+		cur = cur.right
+	}
+}
+
+// FirstEntry 返回最小的键值对，map为空时返回false
+func (p *PersistentTreeMap[K, V]) FirstEntry() (K, V, bool) {
+	n := p.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n.keyValue()
+}
+
+// LastEntry 返回最大的键值对，map为空时返回false
+func (p *PersistentTreeMap[K, V]) LastEntry() (K, V, bool) {
+	n := p.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.keyValue()
+}
+
+// FloorKey 返回小于等于 key 的最大的键，不存在时返回false
+func (p *PersistentTreeMap[K, V]) FloorKey(key K) (K, bool) {
+	k, _, ok := p.FloorEntry(key)
+	return k, ok
+}
+
+// FloorEntry 返回小于等于 key 的最大的键值对，不存在时返回false
+func (p *PersistentTreeMap[K, V]) FloorEntry(key K) (K, V, bool) {
+	return p.floor(key, true).keyValue()
+}
+
+// LowerKey 返回严格小于 key 的最大的键，不存在时返回false
+func (p *PersistentTreeMap[K, V]) LowerKey(key K) (K, bool) {
+	k, _, ok := p.LowerEntry(key)
+	return k, ok
+}
+
+// LowerEntry 返回严格小于 key 的最大的键值对，不存在时返回false
+func (p *PersistentTreeMap[K, V]) LowerEntry(key K) (K, V, bool) {
+	return p.floor(key, false).keyValue()
+}
+
+// CeilingKey 返回大于等于 key 的最小的键，不存在时返回false
+func (p *PersistentTreeMap[K, V]) CeilingKey(key K) (K, bool) {
+	k, _, ok := p.CeilingEntry(key)
+	return k, ok
+}
+
+// CeilingEntry 返回大于等于 key 的最小的键值对，不存在时返回false
+func (p *PersistentTreeMap[K, V]) CeilingEntry(key K) (K, V, bool) {
+	return p.ceiling(key, true).keyValue()
+}
+
+// HigherKey 返回严格大于 key 的最小的键，不存在时返回false
+func (p *PersistentTreeMap[K, V]) HigherKey(key K) (K, bool) {
+	k, _, ok := p.HigherEntry(key)
+	return k, ok
+}
+
+// HigherEntry 返回严格大于 key 的最小的键值对，不存在时返回false
+func (p *PersistentTreeMap[K, V]) HigherEntry(key K) (K, V, bool) {
+	return p.ceiling(key, false).keyValue()
+}
+
+func (p *PersistentTreeMap[K, V]) withRoot(root *persistentNode[K, V], size int) *PersistentTreeMap[K, V] {
+	return &PersistentTreeMap[K, V]{
+		root:    root,
+		compare: p.compare,
+		size:    size,
+	}
+}
+
+// put 返回放入键值对之后的新子树，以及是否新增了节点。只复制路径上的节点，n 本身不会被修改
+func (p *PersistentTreeMap[K, V]) put(n *persistentNode[K, V], key K, value V) (*persistentNode[K, V], bool) {
+	if n == nil {
+		return newPersistentNode[K, V](key, value, nil, nil), true
+	}
+	cmp := p.compare(key, n.key)
+	if cmp < 0 {
+		left, added := p.put(n.left, key, value)
+		return balancePersistentNode(n.key, n.value, left, n.right), added
+	}
+	if cmp > 0 {
+		right, added := p.put(n.right, key, value)
+		return balancePersistentNode(n.key, n.value, n.left, right), added
+	}
+	return newPersistentNode(key, value, n.left, n.right), false
+}
+
+// delete 返回删除 key 之后的新子树。key 不存在时直接返回 n，不复制任何节点
+func (p *PersistentTreeMap[K, V]) delete(n *persistentNode[K, V], key K) (*persistentNode[K, V], V, bool) {
+	if n == nil {
+		var zero V
+		return nil, zero, false
+	}
+	cmp := p.compare(key, n.key)
+	if cmp < 0 {
+		left, value, ok := p.delete(n.left, key)
+		if !ok {
+			return n, value, false
+		}
+		return balancePersistentNode(n.key, n.value, left, n.right), value, true
+	}
+	if cmp > 0 {
+		right, value, ok := p.delete(n.right, key)
+		if !ok {
+			return n, value, false
+		}
+		return balancePersistentNode(n.key, n.value, n.left, right), value, true
+	}
+	if n.left == nil {
+		return n.right, n.value, true
+	}
+	if n.right == nil {
+		return n.left, n.value, true
+	}
+	// 有两个子节点，用右子树中最小的节点（后继节点）替换当前节点
+	successor := n.right
+	for successor.left != nil {
+		successor = successor.left
+	}
+	return balancePersistentNode(successor.key, successor.value, n.left, deleteMinPersistentNode(n.right)), n.value, true
+}
+
+// floor 返回小于（inclusive 为 true 时小于等于）key 的最大节点
+func (p *PersistentTreeMap[K, V]) floor(key K, inclusive bool) *persistentNode[K, V] {
+	var res *persistentNode[K, V]
+	n := p.root
+	for n != nil {
+		cmp := p.compare(key, n.key)
+		if cmp == 0 && inclusive {
+			return n
+		}
+		if cmp > 0 {
+			res = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return res
+}
+
+// ceiling 返回大于（inclusive 为 true 时大于等于）key 的最小节点
+func (p *PersistentTreeMap[K, V]) ceiling(key K, inclusive bool) *persistentNode[K, V] {
+	var res *persistentNode[K, V]
+	n := p.root
+	for n != nil {
+		cmp := p.compare(key, n.key)
+		if cmp == 0 && inclusive {
+			return n
+		}
+		if cmp < 0 {
+			res = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return res
+}
+
+func newPersistentNode[K any, V any](key K, value V, left *persistentNode[K, V], right *persistentNode[K, V]) *persistentNode[K, V] {
+	return &persistentNode[K, V]{
+		key:    key,
+		value:  value,
+		height: maxInt(left.getHeight(), right.getHeight()) + 1,
+		left:   left,
+		right:  right,
+	}
+}
+
+// balancePersistentNode 创建以 key 为根、left 和 right 为子树的新节点，必要时通过旋转恢复平衡
+// left 和 right 的高度差不超过2，旋转时同样只创建新节点
+func balancePersistentNode[K any, V any](key K, value V, left *persistentNode[K, V], right *persistentNode[K, V]) *persistentNode[K, V] {
+	leftHeight, rightHeight := left.getHeight(), right.getHeight()
+	if leftHeight > rightHeight+1 {
+		if left.left.getHeight() >= left.right.getHeight() {
+			// LL：右旋
+			return newPersistentNode(left.key, left.value, left.left,
+				newPersistentNode(key, value, left.right, right))
+		}
+		// LR：先左旋再右旋
+		lr := left.right
+		return newPersistentNode(lr.key, lr.value,
+			newPersistentNode(left.key, left.value, left.left, lr.left),
+			newPersistentNode(key, value, lr.right, right))
+	}
+	if rightHeight > leftHeight+1 {
+		if right.right.getHeight() >= right.left.getHeight() {
+			// RR：左旋
+			return newPersistentNode(right.key, right.value,
+				newPersistentNode(key, value, left, right.left), right.right)
+		}
+		// RL：先右旋再左旋
+		rl := right.left
+		return newPersistentNode(rl.key, rl.value,
+			newPersistentNode(key, value, left, rl.left),
+			newPersistentNode(right.key, right.value, rl.right, right.right))
+	}
+	return newPersistentNode(key, value, left, right)
+}
+
+// deleteMinPersistentNode 返回删除最小节点之后的新子树
+func deleteMinPersistentNode[K any, V any](n *persistentNode[K, V]) *persistentNode[K, V] {
+	if n.left == nil {
+		return n.right
+	}
+	return balancePersistentNode(n.key, n.value, deleteMinPersistentNode(n.left), n.right)
+}
+
+func (n *persistentNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *persistentNode[K, V]) keyValue() (K, V, bool) {
+	if n == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
@@ -0,0 +1,260 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestNewPersistentTreeMap(t *testing.T) {
+	_, err := NewPersistentTreeMap[int, int](nil)
+	assert.Equal(t, errTreeMapComparatorIsNull, err)
+
+	p, err := NewPersistentTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, p.Len())
+	assert.Equal(t, []int{}, p.Keys())
+}
+
+func TestPersistentTreeMap_Put(t *testing.T) {
+	testCases := []struct {
+		name       string
+		keys       []int
+		wantKeys   []int
+		wantValues []int
+	}{
+		{
+			name:       "empty",
+			keys:       []int{},
+			wantKeys:   []int{},
+			wantValues: []int{},
+		},
+		{
+			name:       "single",
+			keys:       []int{1},
+			wantKeys:   []int{1},
+			wantValues: []int{10},
+		},
+		{
+			name:       "ascending",
+			keys:       []int{1, 2, 3, 4, 5, 6, 7},
+			wantKeys:   []int{1, 2, 3, 4, 5, 6, 7},
+			wantValues: []int{10, 20, 30, 40, 50, 60, 70},
+		},
+		{
+			name:       "descending with duplicates",
+			keys:       []int{5, 4, 3, 3, 2, 1, 5},
+			wantKeys:   []int{1, 2, 3, 4, 5},
+			wantValues: []int{10, 20, 30, 40, 50},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewPersistentTreeMap[int, int](compare())
+			assert.NoError(t, err)
+			for _, k := range tc.keys {
+				p = p.Put(k, k*10)
+			}
+			assert.Equal(t, tc.wantKeys, p.Keys())
+			assert.Equal(t, tc.wantValues, p.Values())
+			assert.Equal(t, len(tc.wantKeys), p.Len())
+			assert.True(t, isAVL(p.root, compare()))
+		})
+	}
+}
+
+func TestPersistentTreeMap_Versions(t *testing.T) {
+	v0, err := NewPersistentTreeMap[int, string](compare())
+	assert.NoError(t, err)
+	v1 := v0.Put(1, "a").Put(2, "b").Put(3, "c")
+	v2 := v1.Put(2, "B")
+	v3, val, ok := v2.Delete(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", val)
+
+	// 删除不存在的 key 返回原版本
+	v4, _, ok := v3.Delete(100)
+	assert.False(t, ok)
+	assert.Same(t, v3, v4)
+
+	// 旧版本不受后续修改的影响
+	assert.Equal(t, 0, v0.Len())
+	assert.Equal(t, []string{"a", "b", "c"}, v1.Values())
+	assert.Equal(t, []string{"a", "B", "c"}, v2.Values())
+	assert.Equal(t, []int{2, 3}, v3.Keys())
+	assert.Equal(t, []string{"B", "c"}, v3.Values())
+
+	got, ok := v1.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", got)
+	assert.True(t, v2.Contains(1))
+	assert.False(t, v3.Contains(1))
+}
+
+func TestPersistentTreeMap_StructuralSharing(t *testing.T) {
+	p, err := NewPersistentTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for i := 0; i < 1024; i++ {
+		p = p.Put(i, i)
+	}
+	next := p.Put(0, -1)
+	// 新版本只复制从根到目标节点的路径，其余节点共享
+	assert.NotSame(t, p.root, next.root)
+	assert.NotSame(t, p.root.left, next.root.left)
+	assert.Same(t, p.root.right, next.root.right)
+
+	next, _, _ = p.Delete(1023)
+	assert.Same(t, p.root.left, next.root.left)
+}
+
+func TestPersistentTreeMap_Delete(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	p, err := NewPersistentTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	expected := map[int]int{}
+	for i := 0; i < 2000; i++ {
+		key := rnd.Intn(300)
+		if rnd.Intn(3) == 0 {
+			var ok bool
+			_, wantOk := expected[key]
+			p, _, ok = p.Delete(key)
+			assert.Equal(t, wantOk, ok)
+			delete(expected, key)
+		} else {
+			p = p.Put(key, i)
+			expected[key] = i
+		}
+		assert.Equal(t, len(expected), p.Len())
+	}
+	assert.True(t, isAVL(p.root, compare()))
+	for k, v := range expected {
+		got, ok := p.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, v, got)
+	}
+	keys := p.Keys()
+	for i := 1; i < len(keys); i++ {
+		assert.Less(t, keys[i-1], keys[i])
+	}
+}
+
+func TestPersistentTreeMap_Navigable(t *testing.T) {
+	p, err := NewPersistentTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	_, _, ok := p.FirstEntry()
+	assert.False(t, ok)
+	_, _, ok = p.LastEntry()
+	assert.False(t, ok)
+
+	for _, k := range []int{10, 20, 30, 40} {
+		p = p.Put(k, k*10)
+	}
+	testCases := []struct {
+		name    string
+		find    func(key int) (int, bool)
+		key     int
+		wantKey int
+		wantOk  bool
+	}{
+		{name: "floor exact", find: p.FloorKey, key: 20, wantKey: 20, wantOk: true},
+		{name: "floor between", find: p.FloorKey, key: 25, wantKey: 20, wantOk: true},
+		{name: "floor none", find: p.FloorKey, key: 5},
+		{name: "lower exact", find: p.LowerKey, key: 20, wantKey: 10, wantOk: true},
+		{name: "lower none", find: p.LowerKey, key: 10},
+		{name: "ceiling exact", find: p.CeilingKey, key: 30, wantKey: 30, wantOk: true},
+		{name: "ceiling between", find: p.CeilingKey, key: 25, wantKey: 30, wantOk: true},
+		{name: "ceiling none", find: p.CeilingKey, key: 45},
+		{name: "higher exact", find: p.HigherKey, key: 30, wantKey: 40, wantOk: true},
+		{name: "higher none", find: p.HigherKey, key: 40},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, ok := tc.find(tc.key)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantKey, key)
+		})
+	}
+
+	k, v, ok := p.FloorEntry(35)
+	assert.True(t, ok)
+	assert.Equal(t, []int{30, 300}, []int{k, v})
+	k, v, ok = p.HigherEntry(10)
+	assert.True(t, ok)
+	assert.Equal(t, []int{20, 200}, []int{k, v})
+	k, v, ok = p.FirstEntry()
+	assert.True(t, ok)
+	assert.Equal(t, []int{10, 100}, []int{k, v})
+	k, v, ok = p.LastEntry()
+	assert.True(t, ok)
+	assert.Equal(t, []int{40, 400}, []int{k, v})
+}
+
+func TestPersistentTreeMap_Range(t *testing.T) {
+	p, err := NewPersistentTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for i := 5; i > 0; i-- {
+		p = p.Put(i, i)
+	}
+	keys := make([]int, 0)
+	p.Range(func(key int, value int) bool {
+		keys = append(keys, key)
+		return key < 3
+	})
+	assert.Equal(t, []int{1, 2, 3}, keys)
+}
+
+// TestPersistentTreeMap_Concurrent 写者不断生成新版本，读者无锁地读取旧版本，需要配合 -race 运行
+func TestPersistentTreeMap_Concurrent(t *testing.T) {
+	p, err := NewPersistentTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		p = p.Put(i, i)
+	}
+	snapshot := p
+
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.Equal(t, 100, snapshot.Len())
+				v, ok := snapshot.Get(i)
+				assert.True(t, ok)
+				assert.Equal(t, i, v)
+				assert.Equal(t, 100, len(snapshot.Keys()))
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cur := snapshot
+		for i := 0; i < 100; i++ {
+			cur = cur.Put(i, -i)
+			cur, _, _ = cur.Delete(i)
+		}
+		assert.Equal(t, 0, cur.Len())
+	}()
+	wg.Wait()
+	assert.Equal(t, 100, snapshot.Len())
+}
+
+// isAVL 校验有序性、每个节点的高度以及平衡因子
+func isAVL[K any, V any](n *persistentNode[K, V], cmp func(src, dst K) int) bool {
+	if n == nil {
+		return true
+	}
+	if n.left != nil && cmp(n.left.key, n.key) >= 0 {
+		return false
+	}
+	if n.right != nil && cmp(n.right.key, n.key) <= 0 {
+		return false
+	}
+	lh, rh := n.left.getHeight(), n.right.getHeight()
+	if n.height != maxInt(lh, rh)+1 || lh-rh > 1 || rh-lh > 1 {
+		return false
+	}
+	return isAVL(n.left, cmp) && isAVL(n.right, cmp)
+}
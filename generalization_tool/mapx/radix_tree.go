@@ -0,0 +1,272 @@
+package mapx
+
+import (
+	"sort"
+	"strings"
+)
+
+var (
+	_ Map[string, any] = (*RadixTree[string, any])(nil)
+	_ Map[[]byte, any] = (*RadixTree[[]byte, any])(nil)
+)
+
+// RadixTree 基于基数树（压缩前缀树）实现的 map，key 可以是 string 或者 []byte，不是并发安全的
+// 只有一个子节点并且本身没有值的节点会和子节点合并，所以每条边上保存的是一段公共前缀而不是单个字节。
+// 除了普通的 map 操作以外，还支持最长前缀匹配和按前缀遍历，适合路由表、配置项的命名空间等场景。
+// 遍历按照 key 的字节序从小到大进行
+type RadixTree[K ~string | ~[]byte, V any] struct {
+	root   *radixNode[V]
+	length int
+}
+
+type radixNode[V any] struct {
+	// prefix 从父节点到当前节点的边上的前缀，根节点的 prefix 为空
+	prefix string
+	// children 按照 prefix 的第一个字节从小到大排列，不同子节点的第一个字节一定不同
+	children []*radixNode[V]
+	value    V
+	hasValue bool
+}
+
+// NewRadixTree 创建一个空的 RadixTree
+func NewRadixTree[K ~string | ~[]byte, V any]() *RadixTree[K, V] {
+	return &RadixTree[K, V]{
+		root: &radixNode[V]{},
+	}
+}
+
+// Put 插入键值对，若已存在该key，value将会被替换
+func (r *RadixTree[K, V]) Put(key K, value V) error {
+	s := string(key)
+	n := r.root
+	for {
+		if s == "" {
+			if !n.hasValue {
+				r.length++
+			}
+			n.value, n.hasValue = value, true
+			return nil
+		}
+		i, c := n.child(s[0])
+		if c == nil {
+			n.insertChild(i, &radixNode[V]{prefix: s, value: value, hasValue: true})
+			r.length++
+			return nil
+		}
+		common := commonPrefixLen(s, c.prefix)
+		if common == len(c.prefix) {
+			s = s[common:]
+			n = c
+			continue
+		}
+		// s 和 c 只有部分前缀相同，从公共前缀处拆分 c
+		mid := &radixNode[V]{prefix: c.prefix[:common], children: []*radixNode[V]{c}}
+		c.prefix = c.prefix[common:]
+		n.children[i] = mid
+		s = s[common:]
+		if s == "" {
+			mid.value, mid.hasValue = value, true
+		} else {
+			j, _ := mid.child(s[0])
+			mid.insertChild(j, &radixNode[V]{prefix: s, value: value, hasValue: true})
+		}
+		r.length++
+		return nil
+	}
+}
+
+// Get 返回 key 对应的 value，若未找到则会返回false
+func (r *RadixTree[K, V]) Get(key K) (V, bool) {
+	s := string(key)
+	n := r.root
+	for s != "" {
+		_, c := n.child(s[0])
+		if c == nil || !strings.HasPrefix(s, c.prefix) {
+			var zero V
+			return zero, false
+		}
+		s = s[len(c.prefix):]
+		n = c
+	}
+	return n.value, n.hasValue
+}
+
+// Delete 删除 key 对应的键值对，并返回被删除的值
+// 删除之后会合并多余的节点，保证树的结构和直接插入剩余的键得到的结构相同
+func (r *RadixTree[K, V]) Delete(key K) (V, bool) {
+	var zero V
+	s := string(key)
+	var parent *radixNode[V]
+	index := 0
+	n := r.root
+	for s != "" {
+		i, c := n.child(s[0])
+		if c == nil || !strings.HasPrefix(s, c.prefix) {
+			return zero, false
+		}
+		s = s[len(c.prefix):]
+		parent, index, n = n, i, c
+	}
+	if !n.hasValue {
+		return zero, false
+	}
+	value := n.value
+	n.value, n.hasValue = zero, false
+	r.length--
+
+	if parent == nil {
+		return value, true
+	}
+	switch len(n.children) {
+	case 0:
+		parent.removeChild(index)
+		if parent != r.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		n.mergeChild()
+	}
+	return value, true
+}
+
+// Keys 返回全部的键，按照字节序从小到大的顺序
+func (r *RadixTree[K, V]) Keys() []K {
+	keys := make([]K, 0, r.length)
+	r.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values 返回全部的值，按照 key 的字节序从小到大的顺序
+func (r *RadixTree[K, V]) Values() []V {
+	values := make([]V, 0, r.length)
+	r.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Len 返回键值对数量
+func (r *RadixTree[K, V]) Len() int {
+	return r.length
+}
+
+// Contains 判断 key 是否存在
+func (r *RadixTree[K, V]) Contains(key K) bool {
+	_, ok := r.Get(key)
+	return ok
+}
+
+// Clear 删除所有键值对
+func (r *RadixTree[K, V]) Clear() {
+	r.root = &radixNode[V]{}
+	r.length = 0
+}
+
+// Range 按照 key 的字节序从小到大遍历，fn 返回 false 时停止遍历
+func (r *RadixTree[K, V]) Range(fn func(key K, value V) bool) {
+	r.walk(r.root, "", fn)
+}
+
+// LongestPrefixMatch 返回是 key 的前缀的键中最长的那一个，以及对应的值，不存在时返回false
+// 例如存在 "/api" 和 "/api/user" 时，"/api/user/1" 匹配到的是 "/api/user"
+func (r *RadixTree[K, V]) LongestPrefixMatch(key K) (K, V, bool) {
+	s := string(key)
+	var matchedKey K
+	var matchedValue V
+	matched := false
+	consumed := 0
+	n := r.root
+	for {
+		if n.hasValue {
+			matchedKey, matchedValue, matched = K(s[:consumed]), n.value, true
+		}
+		if consumed == len(s) {
+			break
+		}
+		_, c := n.child(s[consumed])
+		if c == nil || !strings.HasPrefix(s[consumed:], c.prefix) {
+			break
+		}
+		consumed += len(c.prefix)
+		n = c
+	}
+	return matchedKey, matchedValue, matched
+}
+
+// WalkPrefix 按照字节序从小到大遍历所有以 prefix 开头的键值对，fn 返回 false 时停止遍历
+func (r *RadixTree[K, V]) WalkPrefix(prefix K, fn func(key K, value V) bool) {
+	s := string(prefix)
+	consumed := 0
+	n := r.root
+	for consumed < len(s) {
+		_, c := n.child(s[consumed])
+		if c == nil {
+			return
+		}
+		rest := s[consumed:]
+		if strings.HasPrefix(rest, c.prefix) {
+			consumed += len(c.prefix)
+			n = c
+			continue
+		}
+		// prefix 在 c 的边上结束，c 下面所有的键都以 prefix 开头
+		if strings.HasPrefix(c.prefix, rest) {
+			r.walk(c, s[:consumed]+c.prefix, fn)
+		}
+		return
+	}
+	r.walk(n, s, fn)
+}
+
+// walk 先序遍历以 n 为根的子树，key 是从根节点到 n 的完整的键，返回 false 表示停止遍历
+func (r *RadixTree[K, V]) walk(n *radixNode[V], key string, fn func(key K, value V) bool) bool {
+	if n.hasValue && !fn(K(key), n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !r.walk(c, key+c.prefix, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// child 返回第一个字节为 b 的子节点，不存在时返回 nil 以及应该插入的位置
+func (n *radixNode[V]) child(b byte) (int, *radixNode[V]) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+	if i < len(n.children) && n.children[i].prefix[0] == b {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+func (n *radixNode[V]) insertChild(i int, c *radixNode[V]) {
+	n.children = insertAt(n.children, i, c)
+}
+
+func (n *radixNode[V]) removeChild(i int) {
+	n.children = removeAt(n.children, i)
+}
+
+// mergeChild 把唯一的子节点合并到 n 中，n 本身不能有值
+func (n *radixNode[V]) mergeChild() {
+	c := n.children[0]
+	n.prefix += c.prefix
+	n.children = c.children
+	n.value, n.hasValue = c.value, c.hasValue
+}
+
+// commonPrefixLen 返回 a 和 b 的公共前缀的长度
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,230 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRadixTree_Put(t *testing.T) {
+	testCases := []struct {
+		name       string
+		keys       []string
+		wantKeys   []string
+		wantValues []int
+	}{
+		{
+			name:       "empty key",
+			keys:       []string{""},
+			wantKeys:   []string{""},
+			wantValues: []int{0},
+		},
+		{
+			name:       "split edge",
+			keys:       []string{"test", "team", "te"},
+			wantKeys:   []string{"te", "team", "test"},
+			wantValues: []int{2, 1, 0},
+		},
+		{
+			name:       "prefix of existing",
+			keys:       []string{"/api/user", "/api", "/"},
+			wantKeys:   []string{"/", "/api", "/api/user"},
+			wantValues: []int{2, 1, 0},
+		},
+		{
+			name:       "overwrite",
+			keys:       []string{"a", "ab", "a"},
+			wantKeys:   []string{"a", "ab"},
+			wantValues: []int{2, 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRadixTree[string, int]()
+			for i, k := range tc.keys {
+				assert.NoError(t, r.Put(k, i))
+			}
+			assert.Equal(t, tc.wantKeys, r.Keys())
+			assert.Equal(t, tc.wantValues, r.Values())
+			assert.Equal(t, len(tc.wantKeys), r.Len())
+			assert.True(t, isRadixTree(r.root, true))
+		})
+	}
+}
+
+func TestRadixTree_Delete(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     []string
+		delete   string
+		wantOk   bool
+		wantKeys []string
+	}{
+		{name: "not found", keys: []string{"test"}, delete: "te", wantKeys: []string{"test"}},
+		{name: "longer than key", keys: []string{"te"}, delete: "test", wantKeys: []string{"te"}},
+		{name: "leaf", keys: []string{"test", "team"}, delete: "team", wantOk: true, wantKeys: []string{"test"}},
+		{name: "inner", keys: []string{"te", "test"}, delete: "te", wantOk: true, wantKeys: []string{"test"}},
+		{name: "inner with children", keys: []string{"te", "test", "team"}, delete: "te", wantOk: true, wantKeys: []string{"team", "test"}},
+		{name: "empty key", keys: []string{"", "a"}, delete: "", wantOk: true, wantKeys: []string{"a"}},
+		{name: "last", keys: []string{"a"}, delete: "a", wantOk: true, wantKeys: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRadixTree[string, string]()
+			for _, k := range tc.keys {
+				assert.NoError(t, r.Put(k, k))
+			}
+			val, ok := r.Delete(tc.delete)
+			assert.Equal(t, tc.wantOk, ok)
+			if ok {
+				assert.Equal(t, tc.delete, val)
+			}
+			assert.Equal(t, tc.wantKeys, r.Keys())
+			assert.Equal(t, len(tc.wantKeys), r.Len())
+			assert.True(t, isRadixTree(r.root, true))
+		})
+	}
+}
+
+func TestRadixTree_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	r := NewRadixTree[string, int]()
+	expected := map[string]int{}
+	randomKey := func() string {
+		b := make([]byte, rnd.Intn(6))
+		for i := range b {
+			b[i] = "abc/"[rnd.Intn(4)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 5000; i++ {
+		key := randomKey()
+		if rnd.Intn(3) == 0 {
+			_, wantOk := expected[key]
+			_, ok := r.Delete(key)
+			assert.Equal(t, wantOk, ok)
+			delete(expected, key)
+		} else {
+			assert.NoError(t, r.Put(key, i))
+			expected[key] = i
+		}
+	}
+	assert.True(t, isRadixTree(r.root, true))
+	assert.Equal(t, len(expected), r.Len())
+	wantKeys := Keys(expected)
+	sort.Strings(wantKeys)
+	assert.Equal(t, wantKeys, r.Keys())
+	for k, v := range expected {
+		got, ok := r.Get(k)
+		assert.True(t, ok)
+		assert.Equal(t, v, got)
+	}
+}
+
+func TestRadixTree_LongestPrefixMatch(t *testing.T) {
+	r := NewRadixTree[string, int]()
+	for i, k := range []string{"/", "/api", "/api/user", "/apple"} {
+		assert.NoError(t, r.Put(k, i))
+	}
+	testCases := []struct {
+		name      string
+		key       string
+		wantKey   string
+		wantValue int
+		wantOk    bool
+	}{
+		{name: "no match", key: "api"},
+		{name: "empty", key: ""},
+		{name: "exact", key: "/api", wantKey: "/api", wantValue: 1, wantOk: true},
+		{name: "longest", key: "/api/user/1", wantKey: "/api/user", wantValue: 2, wantOk: true},
+		{name: "ends inside edge", key: "/api/us", wantKey: "/api", wantValue: 1, wantOk: true},
+		{name: "diverge", key: "/app", wantKey: "/", wantValue: 0, wantOk: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, value, ok := r.LongestPrefixMatch(tc.key)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantKey, key)
+			assert.Equal(t, tc.wantValue, value)
+		})
+	}
+}
+
+func TestRadixTree_WalkPrefix(t *testing.T) {
+	r := NewRadixTree[string, int]()
+	for i, k := range []string{"app.name", "app.db.host", "app.db.port", "apple", "log.level"} {
+		assert.NoError(t, r.Put(k, i))
+	}
+	testCases := []struct {
+		name     string
+		prefix   string
+		wantKeys []string
+	}{
+		{name: "all", prefix: "", wantKeys: []string{"app.db.host", "app.db.port", "app.name", "apple", "log.level"}},
+		{name: "namespace", prefix: "app.db.", wantKeys: []string{"app.db.host", "app.db.port"}},
+		{name: "ends inside edge", prefix: "app.d", wantKeys: []string{"app.db.host", "app.db.port"}},
+		{name: "ends at node", prefix: "app", wantKeys: []string{"app.db.host", "app.db.port", "app.name", "apple"}},
+		{name: "exact key", prefix: "apple", wantKeys: []string{"apple"}},
+		{name: "diverge inside edge", prefix: "app.x", wantKeys: []string{}},
+		{name: "no match", prefix: "x", wantKeys: []string{}},
+		{name: "longer than key", prefix: "apple.pie", wantKeys: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := make([]string, 0)
+			r.WalkPrefix(tc.prefix, func(key string, value int) bool {
+				keys = append(keys, key)
+				return true
+			})
+			assert.Equal(t, tc.wantKeys, keys)
+		})
+	}
+
+	keys := make([]string, 0)
+	r.WalkPrefix("app", func(key string, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal(t, []string{"app.db.host", "app.db.port"}, keys)
+}
+
+func TestRadixTree_Bytes(t *testing.T) {
+	r := NewRadixTree[[]byte, int]()
+	assert.NoError(t, r.Put([]byte("foo"), 1))
+	assert.NoError(t, r.Put([]byte("foobar"), 2))
+	assert.NoError(t, r.Put([]byte{0xff, 0x00}, 3))
+
+	v, ok := r.Get([]byte("foobar"))
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, [][]byte{[]byte("foo"), []byte("foobar"), {0xff, 0x00}}, r.Keys())
+
+	key, v, ok := r.LongestPrefixMatch([]byte("foob"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte("foo"), key)
+	assert.Equal(t, 1, v)
+
+	_, ok = r.Delete([]byte("foo"))
+	assert.True(t, ok)
+	assert.Equal(t, 2, r.Len())
+	r.Clear()
+	assert.Equal(t, 0, r.Len())
+	assert.False(t, r.Contains([]byte("foobar")))
+}
+
+// isRadixTree 校验子节点按照第一个字节有序，并且除根节点以外没有值的节点至少有两个子节点
+func isRadixTree[V any](n *radixNode[V], isRoot bool) bool {
+	if !isRoot && (n.prefix == "" || (!n.hasValue && len(n.children) < 2)) {
+		return false
+	}
+	for i, c := range n.children {
+		if i > 0 && n.children[i-1].prefix[0] >= c.prefix[0] {
+			return false
+		}
+		if !isRadixTree(c, false) {
+			return false
+		}
+	}
+	return true
+}
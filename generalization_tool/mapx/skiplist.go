@@ -0,0 +1,217 @@
+package mapx
+
+import (
+	"errors"
+	"generalization_tool"
+	"math/rand"
+	"time"
+)
+
+var _ Map[any, any] = (*SkipList[any, any])(nil)
+
+var errSkipListComparatorIsNull = errors.New("SkipList：Comparator不能为nil")
+
+const (
+	// skipListMaxLevel 最大层数，按照 1/4 的晋升概率足够容纳 4^32 个元素
+	skipListMaxLevel = 32
+)
+
+// SkipList 基于跳表实现的有序 map，不是并发安全的，并发场景使用 ConcurrentSkipList
+// 与 TreeMap 相比，跳表插入和删除时不需要旋转，只需要修改前驱节点的指针，适合写多的有序索引
+type SkipList[K any, V any] struct {
+	head    *skipListNode[K, V]
+	compare generalization_tool.Comparator[K]
+	// level 当前最高的层数
+	level  int
+	length int
+	rand   *rand.Rand
+}
+
+type skipListNode[K any, V any] struct {
+	key   K
+	value V
+	next  []*skipListNode[K, V]
+}
+
+// NewSkipList 创建一个空的 SkipList，compare不能为nil
+func NewSkipList[K any, V any](compare generalization_tool.Comparator[K]) (*SkipList[K, V], error) {
+	if compare == nil {
+		return nil, errSkipListComparatorIsNull
+	}
+	return &SkipList[K, V]{
+		head:    &skipListNode[K, V]{next: make([]*skipListNode[K, V], skipListMaxLevel)},
+		compare: compare,
+		level:   1,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// Put 插入键值对，若已存在该key，value将会被替换
+func (s *SkipList[K, V]) Put(key K, value V) error {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	cur := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for cur.next[i] != nil && s.compare(cur.next[i].key, key) < 0 {
+			cur = cur.next[i]
+		}
+		update[i] = cur
+	}
+	if next := cur.next[0]; next != nil && s.compare(next.key, key) == 0 {
+		next.value = value
+		return nil
+	}
+
+	level := s.randomLevel()
+	for i := s.level; i < level; i++ {
+		update[i] = s.head
+	}
+	if level > s.level {
+		s.level = level
+	}
+	node := &skipListNode[K, V]{
+		key:   key,
+		value: value,
+		next:  make([]*skipListNode[K, V], level),
+	}
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	s.length++
+	return nil
+}
+
+// Get 返回 key 对应的 value，若未找到则会返回false
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	if node := s.ceilingNode(key); node != nil && s.compare(node.key, key) == 0 {
+		return node.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete 删除 key 对应的键值对，并返回被删除的值
+func (s *SkipList[K, V]) Delete(key K) (V, bool) {
+	var update [skipListMaxLevel]*skipListNode[K, V]
+	cur := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for cur.next[i] != nil && s.compare(cur.next[i].key, key) < 0 {
+			cur = cur.next[i]
+		}
+		update[i] = cur
+	}
+	node := cur.next[0]
+	if node == nil || s.compare(node.key, key) != 0 {
+		var zero V
+		return zero, false
+	}
+	for i := 0; i < len(node.next); i++ {
+		update[i].next[i] = node.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.length--
+	return node.value, true
+}
+
+// Keys 返回全部的键，按照 key 从小到大的顺序
+func (s *SkipList[K, V]) Keys() []K {
+	keys := make([]K, 0, s.length)
+	s.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values 返回全部的值，按照 key 从小到大的顺序
+func (s *SkipList[K, V]) Values() []V {
+	values := make([]V, 0, s.length)
+	s.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Len 返回键值对数量
+func (s *SkipList[K, V]) Len() int {
+	return s.length
+}
+
+// Contains 判断 key 是否存在
+func (s *SkipList[K, V]) Contains(key K) bool {
+	_, ok := s.Get(key)
+	return ok
+}
+
+// Clear 删除所有键值对
+func (s *SkipList[K, V]) Clear() {
+	for i := range s.head.next {
+		s.head.next[i] = nil
+	}
+	s.level = 1
+	s.length = 0
+}
+
+// Range 按照 key 从小到大的顺序遍历，fn 返回 false 时停止遍历
+func (s *SkipList[K, V]) Range(fn func(key K, value V) bool) {
+	for cur := s.head.next[0]; cur != nil; cur = cur.next[0] {
+		if !fn(cur.key, cur.value) {
+			return
+		}
+	}
+}
+
+// AscendFrom 从大于 from（inclusive 为 true 时大于等于）的第一个键开始按顺序遍历，fn 返回 false 时停止遍历
+func (s *SkipList[K, V]) AscendFrom(from K, inclusive bool, fn func(key K, value V) bool) {
+	for cur := s.lowNode(from, inclusive); cur != nil; cur = cur.next[0] {
+		if !fn(cur.key, cur.value) {
+			return
+		}
+	}
+}
+
+// AscendRange 按顺序遍历 key 在 from 和 to 之间的键值对，fromInclusive、toInclusive 分别表示是否包含两个端点
+// 时间复杂度是 O(log n + k)，k 是区间内键值对的数量
+func (s *SkipList[K, V]) AscendRange(from K, fromInclusive bool, to K, toInclusive bool, fn func(key K, value V) bool) {
+	for cur := s.lowNode(from, fromInclusive); cur != nil; cur = cur.next[0] {
+		cmp := s.compare(cur.key, to)
+		if cmp > 0 || (cmp == 0 && !toInclusive) {
+			return
+		}
+		if !fn(cur.key, cur.value) {
+			return
+		}
+	}
+}
+
+// ceilingNode 返回大于等于 key 的第一个节点
+func (s *SkipList[K, V]) ceilingNode(key K) *skipListNode[K, V] {
+	cur := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for cur.next[i] != nil && s.compare(cur.next[i].key, key) < 0 {
+			cur = cur.next[i]
+		}
+	}
+	return cur.next[0]
+}
+
+// lowNode 返回区间下界对应的第一个节点
+func (s *SkipList[K, V]) lowNode(from K, inclusive bool) *skipListNode[K, V] {
+	node := s.ceilingNode(from)
+	if !inclusive && node != nil && s.compare(node.key, from) == 0 {
+		node = node.next[0]
+	}
+	return node
+}
+
+// randomLevel 每一层以 1/4 的概率晋升到上一层
+func (s *SkipList[K, V]) randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && s.rand.Int63()&3 == 0 {
+		level++
+	}
+	return level
+}
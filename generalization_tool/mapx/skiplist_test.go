@@ -0,0 +1,212 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestNewSkipList(t *testing.T) {
+	_, err := NewSkipList[int, int](nil)
+	assert.Equal(t, errSkipListComparatorIsNull, err)
+
+	s, err := NewSkipList[int, int](compare())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, []int{}, s.Keys())
+}
+
+func TestSkipList_Put(t *testing.T) {
+	testCases := []struct {
+		name       string
+		keys       []int
+		wantKeys   []int
+		wantValues []int
+	}{
+		{
+			name:       "single",
+			keys:       []int{1},
+			wantKeys:   []int{1},
+			wantValues: []int{10},
+		},
+		{
+			name:       "unordered",
+			keys:       []int{5, 1, 4, 2, 3},
+			wantKeys:   []int{1, 2, 3, 4, 5},
+			wantValues: []int{10, 20, 30, 40, 50},
+		},
+		{
+			name:       "duplicates",
+			keys:       []int{2, 2, 1, 1},
+			wantKeys:   []int{1, 2},
+			wantValues: []int{10, 20},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewSkipList[int, int](compare())
+			assert.NoError(t, err)
+			for _, k := range tc.keys {
+				assert.NoError(t, s.Put(k, k*10))
+			}
+			assert.Equal(t, tc.wantKeys, s.Keys())
+			assert.Equal(t, tc.wantValues, s.Values())
+			assert.Equal(t, len(tc.wantKeys), s.Len())
+		})
+	}
+}
+
+func TestSkipList_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	s, err := NewSkipList[int, int](compare())
+	assert.NoError(t, err)
+	tm, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for i := 0; i < 5000; i++ {
+		key := rnd.Intn(500)
+		if rnd.Intn(3) == 0 {
+			v1, ok1 := s.Delete(key)
+			v2, ok2 := tm.Delete(key)
+			assert.Equal(t, ok2, ok1)
+			assert.Equal(t, v2, v1)
+		} else {
+			assert.NoError(t, s.Put(key, i))
+			assert.NoError(t, tm.Put(key, i))
+		}
+	}
+	assert.Equal(t, tm.Len(), s.Len())
+	assert.Equal(t, tm.Keys(), s.Keys())
+	assert.Equal(t, tm.Values(), s.Values())
+	for i := 0; i < 500; i++ {
+		v1, ok1 := s.Get(i)
+		v2, ok2 := tm.Get(i)
+		assert.Equal(t, ok2, ok1)
+		assert.Equal(t, v2, v1)
+	}
+}
+
+func TestSkipList_AscendRange(t *testing.T) {
+	testCases := []struct {
+		name          string
+		from          int
+		fromInclusive bool
+		to            int
+		toInclusive   bool
+		wantKeys      []int
+	}{
+		{name: "closed", from: 2, fromInclusive: true, to: 6, toInclusive: true, wantKeys: []int{2, 4, 6}},
+		{name: "open", from: 2, to: 6, wantKeys: []int{4}},
+		{name: "between keys", from: 1, to: 7, wantKeys: []int{2, 4, 6}},
+		{name: "empty", from: 5, to: 6, wantKeys: []int{}},
+		{name: "from greater than to", from: 8, fromInclusive: true, to: 2, toInclusive: true, wantKeys: []int{}},
+		{name: "beyond", from: 9, fromInclusive: true, to: 20, toInclusive: true, wantKeys: []int{}},
+	}
+	s, err := NewSkipList[int, int](compare())
+	assert.NoError(t, err)
+	for i := 8; i > 0; i -= 2 {
+		assert.NoError(t, s.Put(i, i))
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := make([]int, 0)
+			s.AscendRange(tc.from, tc.fromInclusive, tc.to, tc.toInclusive, func(key int, value int) bool {
+				keys = append(keys, key)
+				return true
+			})
+			assert.Equal(t, tc.wantKeys, keys)
+		})
+	}
+
+	keys := make([]int, 0)
+	s.AscendFrom(4, false, func(key int, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{6, 8}, keys)
+
+	keys = keys[:0]
+	s.AscendFrom(0, true, func(key int, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	assert.Equal(t, []int{2, 4}, keys)
+}
+
+func TestSkipList_Clear(t *testing.T) {
+	s, err := NewSkipList[int, int](compare())
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, s.Put(i, i))
+	}
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Contains(1))
+	assert.NoError(t, s.Put(1, 1))
+	assert.Equal(t, []int{1}, s.Keys())
+}
+
+// BenchmarkSkipList 对比 SkipList 和 TreeMap 在写多场景下的性能
+func BenchmarkSkipList(b *testing.B) {
+	const keys = 1 << 16
+	rnd := rand.New(rand.NewSource(1))
+	data := make([]int, keys)
+	for i := range data {
+		data[i] = rnd.Int()
+	}
+	b.Run("SkipList put", func(b *testing.B) {
+		s, _ := NewSkipList[int, int](compare())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = s.Put(data[i&(keys-1)], i)
+		}
+	})
+	b.Run("TreeMap put", func(b *testing.B) {
+		m, _ := NewTreeMap[int, int](compare())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.Put(data[i&(keys-1)], i)
+		}
+	})
+	b.Run("SkipList put delete", func(b *testing.B) {
+		s, _ := NewSkipList[int, int](compare())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if i%4 == 3 {
+				s.Delete(data[(i-1)&(keys-1)])
+			} else {
+				_ = s.Put(data[i&(keys-1)], i)
+			}
+		}
+	})
+	b.Run("TreeMap put delete", func(b *testing.B) {
+		m, _ := NewTreeMap[int, int](compare())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if i%4 == 3 {
+				m.Delete(data[(i-1)&(keys-1)])
+			} else {
+				_ = m.Put(data[i&(keys-1)], i)
+			}
+		}
+	})
+	b.Run("SkipList get", func(b *testing.B) {
+		s, _ := NewSkipList[int, int](compare())
+		for i, k := range data {
+			_ = s.Put(k, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = s.Get(data[i&(keys-1)])
+		}
+	})
+	b.Run("TreeMap get", func(b *testing.B) {
+		m, _ := NewTreeMap[int, int](compare())
+		for i, k := range data {
+			_ = m.Put(k, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = m.Get(data[i&(keys-1)])
+		}
+	})
+}
@@ -6,7 +6,7 @@ import (
 	"generalization_tool/internal/tree"
 )
 
-var _ mapi[any, any] = (*TreeMap[any, any])(nil)
+var _ Map[any, any] = (*TreeMap[any, any])(nil)
 
 var errTreeMapComparatorIsNull = errors.New("TreeMap：Comparator不能为nil")
 
@@ -73,3 +73,106 @@ func (t *TreeMap[K, V]) Values() []V {
 	_, values := t.tree.KeyValues()
 	return values
 }
+
+// Len 返回键值对数量
+func (t *TreeMap[K, V]) Len() int {
+	return t.tree.Size()
+}
+
+// Contains 判断 key 是否存在
+func (t *TreeMap[K, V]) Contains(key K) bool {
+	_, err := t.tree.Find(key)
+	return err == nil
+}
+
+// Clear 删除所有键值对
+func (t *TreeMap[K, V]) Clear() {
+	t.tree.Clear()
+}
+
+// Range 按照 key 从小到大的顺序遍历，fn 返回 false 时停止遍历
+func (t *TreeMap[K, V]) Range(fn func(key K, value V) bool) {
+	t.tree.Range(fn)
+}
+
+// FloorKey 返回小于等于 key 的最大的键，不存在时返回false
+func (t *TreeMap[K, V]) FloorKey(key K) (K, bool) {
+	k, _, ok := t.tree.Floor(key)
+	return k, ok
+}
+
+// FloorEntry 返回小于等于 key 的最大的键值对，不存在时返回false
+func (t *TreeMap[K, V]) FloorEntry(key K) (K, V, bool) {
+	return t.tree.Floor(key)
+}
+
+// CeilingKey 返回大于等于 key 的最小的键，不存在时返回false
+func (t *TreeMap[K, V]) CeilingKey(key K) (K, bool) {
+	k, _, ok := t.tree.Ceiling(key)
+	return k, ok
+}
+
+// CeilingEntry 返回大于等于 key 的最小的键值对，不存在时返回false
+func (t *TreeMap[K, V]) CeilingEntry(key K) (K, V, bool) {
+	return t.tree.Ceiling(key)
+}
+
+// HigherKey 返回严格大于 key 的最小的键，不存在时返回false
+func (t *TreeMap[K, V]) HigherKey(key K) (K, bool) {
+	k, _, ok := t.tree.Higher(key)
+	return k, ok
+}
+
+// HigherEntry 返回严格大于 key 的最小的键值对，不存在时返回false
+func (t *TreeMap[K, V]) HigherEntry(key K) (K, V, bool) {
+	return t.tree.Higher(key)
+}
+
+// LowerKey 返回严格小于 key 的最大的键，不存在时返回false
+func (t *TreeMap[K, V]) LowerKey(key K) (K, bool) {
+	k, _, ok := t.tree.Lower(key)
+	return k, ok
+}
+
+// LowerEntry 返回严格小于 key 的最大的键值对，不存在时返回false
+func (t *TreeMap[K, V]) LowerEntry(key K) (K, V, bool) {
+	return t.tree.Lower(key)
+}
+
+// FirstEntry 返回最小的键值对，TreeMap为空时返回false
+func (t *TreeMap[K, V]) FirstEntry() (K, V, bool) {
+	return t.tree.Min()
+}
+
+// LastEntry 返回最大的键值对，TreeMap为空时返回false
+func (t *TreeMap[K, V]) LastEntry() (K, V, bool) {
+	return t.tree.Max()
+}
+
+// PollFirst 删除并返回最小的键值对，TreeMap为空时返回false
+func (t *TreeMap[K, V]) PollFirst() (K, V, bool) {
+	key, value, ok := t.tree.Min()
+	if ok {
+		t.tree.Delete(key)
+	}
+	return key, value, ok
+}
+
+// PollLast 删除并返回最大的键值对，TreeMap为空时返回false
+func (t *TreeMap[K, V]) PollLast() (K, V, bool) {
+	key, value, ok := t.tree.Max()
+	if ok {
+		t.tree.Delete(key)
+	}
+	return key, value, ok
+}
+
+// Rank 返回小于 key 的键的数量，key 不需要存在于TreeMap中，时间复杂度 O(log n)
+func (t *TreeMap[K, V]) Rank(key K) int {
+	return t.tree.Rank(key)
+}
+
+// Select 返回第 i 小的键值对（从0开始），i 越界时返回false，时间复杂度 O(log n)
+func (t *TreeMap[K, V]) Select(i int) (K, V, bool) {
+	return t.tree.Select(i)
+}
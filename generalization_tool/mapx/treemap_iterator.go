@@ -0,0 +1,51 @@
+package mapx
+
+import "generalization_tool/internal/tree"
+
+// TreeMapIterator TreeMap 的迭代器，用法：
+//
+//	for it := treeMap.Iterator(); it.Next(); {
+//		key, value := it.Key(), it.Value()
+//	}
+//
+// 迭代器不会复制数据，可以随时 break 提前结束；迭代过程中不能修改 TreeMap
+type TreeMapIterator[K any, V any] struct {
+	it *tree.Iterator[K, V]
+}
+
+// Iterator 返回按照 key 从小到大遍历的迭代器
+func (t *TreeMap[K, V]) Iterator() *TreeMapIterator[K, V] {
+	return &TreeMapIterator[K, V]{it: t.tree.Ascend()}
+}
+
+// IteratorFrom 返回从 key 开始、按照 key 从小到大遍历的迭代器
+// inclusive 为 true 时包含 key 本身，key 不需要存在于TreeMap中
+func (t *TreeMap[K, V]) IteratorFrom(key K, inclusive bool) *TreeMapIterator[K, V] {
+	return &TreeMapIterator[K, V]{it: t.tree.AscendFrom(key, inclusive)}
+}
+
+// DescendingIterator 返回按照 key 从大到小遍历的迭代器
+func (t *TreeMap[K, V]) DescendingIterator() *TreeMapIterator[K, V] {
+	return &TreeMapIterator[K, V]{it: t.tree.Descend()}
+}
+
+// DescendingIteratorFrom 返回从 key 开始、按照 key 从大到小遍历的迭代器
+// inclusive 为 true 时包含 key 本身，key 不需要存在于TreeMap中
+func (t *TreeMap[K, V]) DescendingIteratorFrom(key K, inclusive bool) *TreeMapIterator[K, V] {
+	return &TreeMapIterator[K, V]{it: t.tree.DescendFrom(key, inclusive)}
+}
+
+// Next 移动到下一个键值对，没有更多键值对时返回false
+func (i *TreeMapIterator[K, V]) Next() bool {
+	return i.it.Next()
+}
+
+// Key 返回当前的键，必须在 Next 返回 true 之后调用
+func (i *TreeMapIterator[K, V]) Key() K {
+	return i.it.Key()
+}
+
+// Value 返回当前的值，必须在 Next 返回 true 之后调用
+func (i *TreeMapIterator[K, V]) Value() V {
+	return i.it.Value()
+}
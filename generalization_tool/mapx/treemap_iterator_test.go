@@ -0,0 +1,80 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTreeMapIterator(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for _, k := range []int{30, 10, 50, 20, 40} {
+		assert.NoError(t, treeMap.Put(k, k*10))
+	}
+	testCases := []struct {
+		name     string
+		it       *TreeMapIterator[int, int]
+		wantKeys []int
+	}{
+		{
+			name:     "ascending",
+			it:       treeMap.Iterator(),
+			wantKeys: []int{10, 20, 30, 40, 50},
+		},
+		{
+			name:     "ascending from",
+			it:       treeMap.IteratorFrom(30, true),
+			wantKeys: []int{30, 40, 50},
+		},
+		{
+			name:     "ascending after",
+			it:       treeMap.IteratorFrom(30, false),
+			wantKeys: []int{40, 50},
+		},
+		{
+			name:     "descending",
+			it:       treeMap.DescendingIterator(),
+			wantKeys: []int{50, 40, 30, 20, 10},
+		},
+		{
+			name:     "descending from",
+			it:       treeMap.DescendingIteratorFrom(35, true),
+			wantKeys: []int{30, 20, 10},
+		},
+		{
+			name:     "descending before",
+			it:       treeMap.DescendingIteratorFrom(30, false),
+			wantKeys: []int{20, 10},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := make([]int, 0)
+			for tc.it.Next() {
+				assert.Equal(t, tc.it.Key()*10, tc.it.Value())
+				keys = append(keys, tc.it.Key())
+			}
+			assert.Equal(t, tc.wantKeys, keys)
+		})
+	}
+}
+
+// TestTreeMapIterator_Pagination 以上一页最后一个 key 作为游标分页
+func TestTreeMapIterator_Pagination(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, treeMap.Put(i, i))
+	}
+	page := func(it *TreeMapIterator[int, int], size int) []int {
+		res := make([]int, 0, size)
+		for len(res) < size && it.Next() {
+			res = append(res, it.Key())
+		}
+		return res
+	}
+	assert.Equal(t, []int{0, 1, 2, 3}, page(treeMap.Iterator(), 4))
+	assert.Equal(t, []int{4, 5, 6, 7}, page(treeMap.IteratorFrom(3, false), 4))
+	assert.Equal(t, []int{8, 9}, page(treeMap.IteratorFrom(7, false), 4))
+	assert.Equal(t, []int{}, page(treeMap.IteratorFrom(9, false), 4))
+}
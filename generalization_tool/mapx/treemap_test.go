@@ -3,6 +3,7 @@ package mapx
 import (
 	"generalization_tool"
 	"github.com/stretchr/testify/assert"
+	"strconv"
 	"testing"
 )
 
@@ -377,6 +378,120 @@ func TestTreeMap_Delete(t *testing.T) {
 }
 
 // goos: windows
+func TestTreeMap_Navigable(t *testing.T) {
+	treeMap, err := NewTreeMap[int, string](compare())
+	assert.NoError(t, err)
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		assert.NoError(t, treeMap.Put(k, strconv.Itoa(k)))
+	}
+	testCases := []struct {
+		name    string
+		find    func(key int) (int, bool)
+		findKV  func(key int) (int, string, bool)
+		key     int
+		wantKey int
+		wantOk  bool
+	}{
+		{name: "floor", find: treeMap.FloorKey, findKV: treeMap.FloorEntry, key: 4, wantKey: 3, wantOk: true},
+		{name: "floor equal", find: treeMap.FloorKey, findKV: treeMap.FloorEntry, key: 5, wantKey: 5, wantOk: true},
+		{name: "floor not found", find: treeMap.FloorKey, findKV: treeMap.FloorEntry, key: 0},
+		{name: "ceiling", find: treeMap.CeilingKey, findKV: treeMap.CeilingEntry, key: 4, wantKey: 5, wantOk: true},
+		{name: "ceiling equal", find: treeMap.CeilingKey, findKV: treeMap.CeilingEntry, key: 9, wantKey: 9, wantOk: true},
+		{name: "ceiling not found", find: treeMap.CeilingKey, findKV: treeMap.CeilingEntry, key: 10},
+		{name: "higher", find: treeMap.HigherKey, findKV: treeMap.HigherEntry, key: 5, wantKey: 7, wantOk: true},
+		{name: "higher not found", find: treeMap.HigherKey, findKV: treeMap.HigherEntry, key: 9},
+		{name: "lower", find: treeMap.LowerKey, findKV: treeMap.LowerEntry, key: 5, wantKey: 3, wantOk: true},
+		{name: "lower not found", find: treeMap.LowerKey, findKV: treeMap.LowerEntry, key: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, ok := tc.find(tc.key)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantKey, key)
+			key, value, ok := tc.findKV(tc.key)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantKey, key)
+			if ok {
+				assert.Equal(t, strconv.Itoa(tc.wantKey), value)
+			} else {
+				assert.Equal(t, "", value)
+			}
+		})
+	}
+}
+
+func TestTreeMap_FirstLast(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	_, _, ok := treeMap.FirstEntry()
+	assert.False(t, ok)
+	_, _, ok = treeMap.PollLast()
+	assert.False(t, ok)
+
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		assert.NoError(t, treeMap.Put(k, k*10))
+	}
+	key, value, ok := treeMap.FirstEntry()
+	assert.True(t, ok)
+	assert.Equal(t, 1, key)
+	assert.Equal(t, 10, value)
+	key, value, ok = treeMap.LastEntry()
+	assert.True(t, ok)
+	assert.Equal(t, 9, key)
+	assert.Equal(t, 90, value)
+	assert.Equal(t, 5, treeMap.Len())
+
+	key, value, ok = treeMap.PollFirst()
+	assert.True(t, ok)
+	assert.Equal(t, 1, key)
+	assert.Equal(t, 10, value)
+	key, value, ok = treeMap.PollLast()
+	assert.True(t, ok)
+	assert.Equal(t, 9, key)
+	assert.Equal(t, 90, value)
+	assert.Equal(t, []int{3, 5, 7}, treeMap.Keys())
+	assert.Equal(t, 3, treeMap.Len())
+}
+
+func TestTreeMap_RankSelect(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		assert.NoError(t, treeMap.Put(k, k*10))
+	}
+	testCases := []struct {
+		name     string
+		key      int
+		wantRank int
+	}{
+		{name: "min", key: 10, wantRank: 0},
+		{name: "middle", key: 30, wantRank: 2},
+		{name: "max", key: 50, wantRank: 4},
+		{name: "not exist", key: 35, wantRank: 3},
+		{name: "smaller than all", key: 0, wantRank: 0},
+		{name: "larger than all", key: 100, wantRank: 5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantRank, treeMap.Rank(tc.key))
+		})
+	}
+	for i, k := range []int{10, 20, 30, 40, 50} {
+		key, value, ok := treeMap.Select(i)
+		assert.True(t, ok)
+		assert.Equal(t, k, key)
+		assert.Equal(t, k*10, value)
+	}
+	_, _, ok := treeMap.Select(5)
+	assert.False(t, ok)
+
+	_, _ = treeMap.Delete(30)
+	assert.Equal(t, 2, treeMap.Rank(40))
+	key, _, ok := treeMap.Select(2)
+	assert.True(t, ok)
+	assert.Equal(t, 40, key)
+}
+
 // goarch: amd64
 // pkg: generalization_tool/mapx
 // cpu: Intel(R) Core(TM) i5-9300H CPU @ 2.40GHz
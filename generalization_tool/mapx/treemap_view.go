@@ -0,0 +1,89 @@
+package mapx
+
+import "generalization_tool/internal/tree"
+
+// TreeMapView TreeMap 在某个 key 区间上的只读视图
+// 视图不会复制数据，TreeMap 之后的修改会反映在视图中。
+// 遍历时先定位到区间的下界，再沿着中序遍历向后走，时间复杂度是 O(log n + k)，k 是区间内键值对的数量
+type TreeMapView[K any, V any] struct {
+	tree *tree.RBTree[K, V]
+	lo   tree.Bound[K]
+	hi   tree.Bound[K]
+}
+
+// SubMap 返回 key 在 from 和 to 之间的视图，fromInclusive、toInclusive 分别表示是否包含两个端点
+// from 大于 to 时返回空的视图
+func (t *TreeMap[K, V]) SubMap(from K, fromInclusive bool, to K, toInclusive bool) *TreeMapView[K, V] {
+	return &TreeMapView[K, V]{
+		tree: t.tree,
+		lo:   tree.NewBound(from, fromInclusive),
+		hi:   tree.NewBound(to, toInclusive),
+	}
+}
+
+// HeadMap 返回 key 小于 to（inclusive 为 true 时小于等于）的视图
+func (t *TreeMap[K, V]) HeadMap(to K, inclusive bool) *TreeMapView[K, V] {
+	return &TreeMapView[K, V]{
+		tree: t.tree,
+		hi:   tree.NewBound(to, inclusive),
+	}
+}
+
+// TailMap 返回 key 大于 from（inclusive 为 true 时大于等于）的视图
+func (t *TreeMap[K, V]) TailMap(from K, inclusive bool) *TreeMapView[K, V] {
+	return &TreeMapView[K, V]{
+		tree: t.tree,
+		lo:   tree.NewBound(from, inclusive),
+	}
+}
+
+// Get 返回 key 对应的值，key 不在区间内时返回false
+func (v *TreeMapView[K, V]) Get(key K) (V, bool) {
+	if !v.tree.InRange(key, v.lo, v.hi) {
+		var zero V
+		return zero, false
+	}
+	value, err := v.tree.Find(key)
+	return value, err == nil
+}
+
+// Contains 判断 key 是否在视图中
+func (v *TreeMapView[K, V]) Contains(key K) bool {
+	_, ok := v.Get(key)
+	return ok
+}
+
+// Range 按照 key 从小到大的顺序遍历视图，fn 返回 false 时停止遍历
+func (v *TreeMapView[K, V]) Range(fn func(key K, value V) bool) {
+	v.tree.AscendRange(v.lo, v.hi, fn)
+}
+
+// Keys 按照从小到大的顺序返回视图中所有的键
+func (v *TreeMapView[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	v.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values 按照键从小到大的顺序返回视图中所有的值
+func (v *TreeMapView[K, V]) Values() []V {
+	values := make([]V, 0)
+	v.Range(func(key K, value V) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Len 返回视图中键值对的数量，需要遍历整个区间
+func (v *TreeMapView[K, V]) Len() int {
+	length := 0
+	v.Range(func(key K, value V) bool {
+		length++
+		return true
+	})
+	return length
+}
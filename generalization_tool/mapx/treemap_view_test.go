@@ -0,0 +1,110 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTreeMapView(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	for _, k := range []int{50, 10, 40, 20, 30} {
+		assert.NoError(t, treeMap.Put(k, k*10))
+	}
+	testCases := []struct {
+		name     string
+		view     *TreeMapView[int, int]
+		wantKeys []int
+	}{
+		{
+			name:     "sub map inclusive",
+			view:     treeMap.SubMap(20, true, 40, true),
+			wantKeys: []int{20, 30, 40},
+		},
+		{
+			name:     "sub map exclusive",
+			view:     treeMap.SubMap(20, false, 40, false),
+			wantKeys: []int{30},
+		},
+		{
+			name:     "sub map key not exist",
+			view:     treeMap.SubMap(15, true, 45, true),
+			wantKeys: []int{20, 30, 40},
+		},
+		{
+			name:     "sub map from greater than to",
+			view:     treeMap.SubMap(40, true, 20, true),
+			wantKeys: []int{},
+		},
+		{
+			name:     "head map",
+			view:     treeMap.HeadMap(30, false),
+			wantKeys: []int{10, 20},
+		},
+		{
+			name:     "head map inclusive",
+			view:     treeMap.HeadMap(30, true),
+			wantKeys: []int{10, 20, 30},
+		},
+		{
+			name:     "tail map",
+			view:     treeMap.TailMap(30, false),
+			wantKeys: []int{40, 50},
+		},
+		{
+			name:     "tail map inclusive",
+			view:     treeMap.TailMap(30, true),
+			wantKeys: []int{30, 40, 50},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantKeys, tc.view.Keys())
+			wantValues := make([]int, 0, len(tc.wantKeys))
+			for _, k := range tc.wantKeys {
+				wantValues = append(wantValues, k*10)
+			}
+			assert.Equal(t, wantValues, tc.view.Values())
+			assert.Equal(t, len(tc.wantKeys), tc.view.Len())
+			for _, k := range []int{10, 20, 30, 40, 50} {
+				val, ok := tc.view.Get(k)
+				assert.Equal(t, contains(tc.wantKeys, k), ok)
+				assert.Equal(t, contains(tc.wantKeys, k), tc.view.Contains(k))
+				if ok {
+					assert.Equal(t, k*10, val)
+				}
+			}
+		})
+	}
+}
+
+func TestTreeMapView_Live(t *testing.T) {
+	treeMap, err := NewTreeMap[int, int](compare())
+	assert.NoError(t, err)
+	view := treeMap.SubMap(10, true, 20, true)
+	assert.Equal(t, []int{}, view.Keys())
+
+	// 视图反映 TreeMap 之后的修改
+	assert.NoError(t, treeMap.Put(15, 15))
+	assert.NoError(t, treeMap.Put(25, 25))
+	assert.NoError(t, treeMap.Put(10, 10))
+	assert.Equal(t, []int{10, 15}, view.Keys())
+	treeMap.Delete(10)
+	assert.Equal(t, []int{15}, view.Keys())
+
+	count := 0
+	treeMap.TailMap(0, true).Range(func(key int, value int) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
+
+func contains(keys []int, key int) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package mapx
 
-type mapi[K any, V any] interface {
+// Map 是 mapx 中所有 map 的公共接口
+type Map[K any, V any] interface {
 	Put(key K, value V) error
 	Get(key K) (V, bool)
 	Delete(key K) (V, bool)
@@ -8,4 +9,13 @@ type mapi[K any, V any] interface {
 	Keys() []K
 	// Values 返回所有的值，调用多次拿到的结果不一定相等
 	Values() []V
+	// Len 返回键值对数量
+	Len() int
+	// Contains 判断 key 是否存在
+	Contains(key K) bool
+	// Clear 删除所有键值对
+	Clear()
+	// Range 遍历所有键值对，fn 返回 false 时停止遍历
+	// 遍历顺序与 Keys 一致，遍历过程中不能修改 map
+	Range(fn func(key K, value V) bool)
 }
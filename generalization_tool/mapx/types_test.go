@@ -0,0 +1,194 @@
+package mapx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	testCases := []struct {
+		name string
+		m    func(t *testing.T) Map[int, int]
+		// ordered 为 true 时，Keys 和 Range 按照 key 从小到大的顺序
+		ordered bool
+	}{
+		{
+			name: "BuiltinMap",
+			m: func(t *testing.T) Map[int, int] {
+				return NewBuiltinMap[int, int](10)
+			},
+		},
+		{
+			name: "TreeMap",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewTreeMap[int, int](compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+		{
+			name: "LinkedMap",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewLinkedTreeMap[int, int](compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+		{
+			name: "BTree",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewBTree[int, int](2, compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+		{
+			name: "SkipList",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewSkipList[int, int](compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+		{
+			name: "ConcurrentSkipList",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewConcurrentSkipList[int, int](compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+		{
+			name: "ConcurrentMap",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewConcurrentMap[int, int](4, IntegerHash[int])
+				assert.NoError(t, err)
+				return m
+			},
+		},
+		{
+			name: "ExpiringMap",
+			m: func(t *testing.T) Map[int, int] {
+				return NewExpiringBuiltinMap[int, int](10)
+			},
+		},
+		{
+			name: "LRU",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewTreeMapLRU[int, int](10, compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+		{
+			name: "LFU",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewTreeMapLFU[int, int](10, compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+		{
+			name: "ARC",
+			m: func(t *testing.T) Map[int, int] {
+				m, err := NewTreeMapARC[int, int](10, compare())
+				assert.NoError(t, err)
+				return m
+			},
+			ordered: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := tc.m(t)
+			for i := 1; i <= 5; i++ {
+				assert.NoError(t, m.Put(i, i*10))
+			}
+			assert.NoError(t, m.Put(3, 30))
+			assert.Equal(t, 5, m.Len())
+			assert.True(t, m.Contains(3))
+			assert.False(t, m.Contains(6))
+
+			_, ok := m.Delete(3)
+			assert.True(t, ok)
+			assert.False(t, m.Contains(3))
+			assert.Equal(t, 4, m.Len())
+
+			keys := make([]int, 0)
+			m.Range(func(key int, value int) bool {
+				assert.Equal(t, key*10, value)
+				keys = append(keys, key)
+				return true
+			})
+			if !tc.ordered {
+				sort.Ints(keys)
+			}
+			assert.Equal(t, []int{1, 2, 4, 5}, keys)
+
+			count := 0
+			m.Range(func(key int, value int) bool {
+				count++
+				return count < 2
+			})
+			assert.Equal(t, 2, count)
+
+			m.Clear()
+			assert.Equal(t, 0, m.Len())
+			assert.False(t, m.Contains(1))
+			assert.Equal(t, []int{}, m.Keys())
+			assert.Equal(t, []int{}, m.Values())
+
+			// Clear 之后可以继续使用
+			assert.NoError(t, m.Put(7, 70))
+			assert.Equal(t, []int{7}, m.Keys())
+			assert.Equal(t, []int{70}, m.Values())
+		})
+	}
+}
+
+func TestMap_Hashable(t *testing.T) {
+	maps := map[string]Map[testData, int]{
+		"HashMap":       NewHashMap[testData, int](10),
+		"LinkedHashMap": NewLinkedHashMap[testData, int](10),
+		"ConcurrentMap": NewConcurrentHashMap[testData, int](4),
+	}
+	for name, m := range maps {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				assert.NoError(t, m.Put(newTestData(i), i))
+			}
+			// 覆盖已有的 key 不会改变数量
+			assert.NoError(t, m.Put(newTestData(11), 11))
+			assert.Equal(t, 20, m.Len())
+			_, ok := m.Delete(newTestData(1))
+			assert.True(t, ok)
+			_, ok = m.Delete(newTestData(1))
+			assert.False(t, ok)
+			assert.Equal(t, 19, m.Len())
+			assert.True(t, m.Contains(newTestData(11)))
+			assert.False(t, m.Contains(newTestData(1)))
+
+			sum := 0
+			m.Range(func(key testData, value int) bool {
+				assert.Equal(t, key.id, value)
+				sum += value
+				return true
+			})
+			assert.Equal(t, 190-1, sum)
+
+			m.Clear()
+			assert.Equal(t, 0, m.Len())
+			assert.Equal(t, 0, len(m.Keys()))
+			assert.NoError(t, m.Put(newTestData(1), 1))
+			assert.Equal(t, 1, m.Len())
+		})
+	}
+}
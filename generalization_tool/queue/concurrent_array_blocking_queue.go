@@ -1,5 +1,106 @@
 package queue
 
-// ConcurrentArrayBlockingQueue 有界并发阻塞队列
+import (
+	"context"
+	"sync"
+)
+
+var _ BlockingQueue[any] = (*ConcurrentArrayBlockingQueue[any])(nil)
+
+// ConcurrentArrayBlockingQueue 有界并发阻塞队列，基于环形数组实现
 type ConcurrentArrayBlockingQueue[T any] struct {
+	data []T
+	// head 队首下标，tail 下一个入队元素的下标
+	head  int
+	tail  int
+	count int
+
+	mutex    *sync.Mutex
+	notEmpty *cond
+	notFull  *cond
+
+	zero T
+}
+
+// NewConcurrentArrayBlockingQueue 创建一个容量为 capacity 的有界阻塞队列，capacity 小于等于0时返回 ErrInvalidCapacity
+func NewConcurrentArrayBlockingQueue[T any](capacity int) (*ConcurrentArrayBlockingQueue[T], error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	mutex := &sync.Mutex{}
+	return &ConcurrentArrayBlockingQueue[T]{
+		data:     make([]T, capacity),
+		mutex:    mutex,
+		notEmpty: newCond(mutex),
+		notFull:  newCond(mutex),
+	}, nil
+}
+
+// Enqueue 入队，队列已满时会阻塞直到有空闲位置，或 ctx 超时/被 cancel
+func (c *ConcurrentArrayBlockingQueue[T]) Enqueue(ctx context.Context, t T) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.count == len(c.data) {
+		if err := c.notFull.wait(ctx); err != nil {
+			return err
+		}
+	}
+	c.data[c.tail] = t
+	c.tail++
+	if c.tail == len(c.data) {
+		c.tail = 0
+	}
+	c.count++
+	c.notEmpty.broadcast()
+	return nil
+}
+
+// Dequeue 出队，队列为空时会阻塞直到有元素，或 ctx 超时/被 cancel
+func (c *ConcurrentArrayBlockingQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	if ctx.Err() != nil {
+		return c.zero, ctx.Err()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.count == 0 {
+		if err := c.notEmpty.wait(ctx); err != nil {
+			return c.zero, err
+		}
+	}
+	t := c.data[c.head]
+	// 释放对元素的引用，方便 GC
+	c.data[c.head] = c.zero
+	c.head++
+	if c.head == len(c.data) {
+		c.head = 0
+	}
+	c.count--
+	c.notFull.broadcast()
+	return t, nil
+}
+
+// Len 返回队列中元素的数量
+func (c *ConcurrentArrayBlockingQueue[T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.count
+}
+
+// Cap 返回队列的容量
+func (c *ConcurrentArrayBlockingQueue[T]) Cap() int {
+	return len(c.data)
+}
+
+// AsSlice 按出队顺序返回队列中的元素，没有元素的情况下返回len和cap为0的切片；每次调用都返回一个新切片
+func (c *ConcurrentArrayBlockingQueue[T]) AsSlice() []T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	res := make([]T, 0, c.count)
+	for i := 0; i < c.count; i++ {
+		res = append(res, c.data[(c.head+i)%len(c.data)])
+	}
+	return res
 }
@@ -0,0 +1,219 @@
+package queue
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewConcurrentArrayBlockingQueue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capacity int
+		wantErr  error
+	}{
+		{name: "negative", capacity: -1, wantErr: ErrInvalidCapacity},
+		{name: "zero", capacity: 0, wantErr: ErrInvalidCapacity},
+		{name: "normal", capacity: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := NewConcurrentArrayBlockingQueue[int](tc.capacity)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if err != nil {
+				assert.Nil(t, q)
+				return
+			}
+			assert.Equal(t, tc.capacity, len(q.data))
+		})
+	}
+}
+
+func TestConcurrentArrayBlockingQueue_Enqueue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		q       func() *ConcurrentArrayBlockingQueue[int]
+		timeout time.Duration
+		value   int
+		wantErr error
+		wantRes []int
+	}{
+		{
+			name: "empty",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				return newTestArrayBlockingQueue(3)
+			},
+			timeout: time.Second,
+			value:   1,
+			wantRes: []int{1},
+		},
+		{
+			name: "not full",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				q := newTestArrayBlockingQueue(3)
+				_ = q.Enqueue(context.Background(), 1)
+				return q
+			},
+			timeout: time.Second,
+			value:   2,
+			wantRes: []int{1, 2},
+		},
+		{
+			name: "wrap around",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				q := newTestArrayBlockingQueue(3)
+				_ = q.Enqueue(context.Background(), 1)
+				_ = q.Enqueue(context.Background(), 2)
+				_ = q.Enqueue(context.Background(), 3)
+				_, _ = q.Dequeue(context.Background())
+				return q
+			},
+			timeout: time.Second,
+			value:   4,
+			wantRes: []int{2, 3, 4},
+		},
+		{
+			name: "full and timeout",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				q := newTestArrayBlockingQueue(2)
+				_ = q.Enqueue(context.Background(), 1)
+				_ = q.Enqueue(context.Background(), 2)
+				return q
+			},
+			timeout: 10 * time.Millisecond,
+			value:   3,
+			wantErr: context.DeadlineExceeded,
+			wantRes: []int{1, 2},
+		},
+		{
+			name: "ctx done",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				return newTestArrayBlockingQueue(2)
+			},
+			timeout: -time.Second,
+			value:   1,
+			wantErr: context.DeadlineExceeded,
+			wantRes: []int{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			q := tc.q()
+			err := q.Enqueue(ctx, tc.value)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantRes, q.AsSlice())
+			assert.Equal(t, len(tc.wantRes), q.Len())
+		})
+	}
+}
+
+func TestConcurrentArrayBlockingQueue_Dequeue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		q       func() *ConcurrentArrayBlockingQueue[int]
+		timeout time.Duration
+		wantVal int
+		wantErr error
+		wantRes []int
+	}{
+		{
+			name: "empty and timeout",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				return newTestArrayBlockingQueue(3)
+			},
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+			wantRes: []int{},
+		},
+		{
+			name: "ctx done",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				q := newTestArrayBlockingQueue(3)
+				_ = q.Enqueue(context.Background(), 1)
+				return q
+			},
+			timeout: -time.Second,
+			wantErr: context.DeadlineExceeded,
+			wantRes: []int{1},
+		},
+		{
+			name: "normal",
+			q: func() *ConcurrentArrayBlockingQueue[int] {
+				q := newTestArrayBlockingQueue(3)
+				_ = q.Enqueue(context.Background(), 1)
+				_ = q.Enqueue(context.Background(), 2)
+				return q
+			},
+			timeout: time.Second,
+			wantVal: 1,
+			wantRes: []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			q := tc.q()
+			val, err := q.Dequeue(ctx)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantRes, q.AsSlice())
+		})
+	}
+}
+
+func TestConcurrentArrayBlockingQueue_Block(t *testing.T) {
+	q := newTestArrayBlockingQueue(1)
+	assert.Equal(t, 1, q.Cap())
+	assert.NoError(t, q.Enqueue(context.Background(), 1))
+
+	// 队列已满，入队会阻塞直到有元素出队
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		val, err := q.Dequeue(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, q.Enqueue(ctx, 2))
+	assert.Equal(t, []int{2}, q.AsSlice())
+}
+
+func TestConcurrentArrayBlockingQueue_Concurrent(t *testing.T) {
+	q := newTestArrayBlockingQueue(10)
+	const producers, perProducer = 10, 1000
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for i := 0; i < producers; i++ {
+		go func(base int) {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				assert.NoError(t, q.Enqueue(context.Background(), base+j))
+			}
+		}(i * perProducer)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	for i := 0; i < producers*perProducer; i++ {
+		val, err := q.Dequeue(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, seen[val])
+		seen[val] = true
+	}
+	wg.Wait()
+	assert.Equal(t, 0, q.Len())
+}
+
+// newTestArrayBlockingQueue 创建测试用的队列，capacity 必须大于0
+func newTestArrayBlockingQueue(capacity int) *ConcurrentArrayBlockingQueue[int] {
+	q, err := NewConcurrentArrayBlockingQueue[int](capacity)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
@@ -1 +1,92 @@
 package queue
+
+import (
+	"context"
+	"generalization_tool/list"
+	"sync"
+)
+
+var _ BlockingQueue[any] = (*ConcurrentLinkedBlockingQueue[any])(nil)
+
+// ConcurrentLinkedBlockingQueue 基于链表的并发阻塞队列
+// 如果 maxSize > 0，那么是有界队列，队列已满时入队会阻塞
+// 如果 maxSize <= 0，那么是无界队列，入队永远不会阻塞
+type ConcurrentLinkedBlockingQueue[T any] struct {
+	linkedList *list.LinkedList[T]
+	maxSize    int
+
+	mutex    *sync.Mutex
+	notEmpty *cond
+	notFull  *cond
+
+	zero T
+}
+
+// NewConcurrentLinkedBlockingQueue 创建一个链表阻塞队列，maxSize <= 0 时为无界队列
+func NewConcurrentLinkedBlockingQueue[T any](maxSize int) *ConcurrentLinkedBlockingQueue[T] {
+	mutex := &sync.Mutex{}
+	return &ConcurrentLinkedBlockingQueue[T]{
+		linkedList: list.NewLinkedList[T](),
+		maxSize:    maxSize,
+		mutex:      mutex,
+		notEmpty:   newCond(mutex),
+		notFull:    newCond(mutex),
+	}
+}
+
+// Enqueue 入队，有界队列已满时会阻塞直到有空闲位置，或 ctx 超时/被 cancel
+func (c *ConcurrentLinkedBlockingQueue[T]) Enqueue(ctx context.Context, t T) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.isFull() {
+		if err := c.notFull.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := c.linkedList.Append(t); err != nil {
+		return err
+	}
+	c.notEmpty.broadcast()
+	return nil
+}
+
+// Dequeue 出队，队列为空时会阻塞直到有元素，或 ctx 超时/被 cancel
+func (c *ConcurrentLinkedBlockingQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	if ctx.Err() != nil {
+		return c.zero, ctx.Err()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.linkedList.Len() == 0 {
+		if err := c.notEmpty.wait(ctx); err != nil {
+			return c.zero, err
+		}
+	}
+	t, err := c.linkedList.Delete(0)
+	if err != nil {
+		return c.zero, err
+	}
+	c.notFull.broadcast()
+	return t, nil
+}
+
+func (c *ConcurrentLinkedBlockingQueue[T]) isFull() bool {
+	return c.maxSize > 0 && c.linkedList.Len() >= c.maxSize
+}
+
+// Len 返回队列中元素的数量
+func (c *ConcurrentLinkedBlockingQueue[T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.linkedList.Len()
+}
+
+// AsSlice 按出队顺序返回队列中的元素，没有元素的情况下返回len和cap为0的切片；每次调用都返回一个新切片
+func (c *ConcurrentLinkedBlockingQueue[T]) AsSlice() []T {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.linkedList.AsSlice()
+}
@@ -0,0 +1,168 @@
+package queue
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentLinkedBlockingQueue_Enqueue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		q       func() *ConcurrentLinkedBlockingQueue[int]
+		timeout time.Duration
+		value   int
+		wantErr error
+		wantRes []int
+	}{
+		{
+			name: "bounded not full",
+			q: func() *ConcurrentLinkedBlockingQueue[int] {
+				q := NewConcurrentLinkedBlockingQueue[int](2)
+				_ = q.Enqueue(context.Background(), 1)
+				return q
+			},
+			timeout: time.Second,
+			value:   2,
+			wantRes: []int{1, 2},
+		},
+		{
+			name: "bounded full and timeout",
+			q: func() *ConcurrentLinkedBlockingQueue[int] {
+				q := NewConcurrentLinkedBlockingQueue[int](2)
+				_ = q.Enqueue(context.Background(), 1)
+				_ = q.Enqueue(context.Background(), 2)
+				return q
+			},
+			timeout: 10 * time.Millisecond,
+			value:   3,
+			wantErr: context.DeadlineExceeded,
+			wantRes: []int{1, 2},
+		},
+		{
+			name: "unbounded",
+			q: func() *ConcurrentLinkedBlockingQueue[int] {
+				q := NewConcurrentLinkedBlockingQueue[int](0)
+				for i := 1; i <= 100; i++ {
+					_ = q.Enqueue(context.Background(), i)
+				}
+				return q
+			},
+			timeout: 10 * time.Millisecond,
+			value:   101,
+			wantRes: func() []int {
+				res := make([]int, 0, 101)
+				for i := 1; i <= 101; i++ {
+					res = append(res, i)
+				}
+				return res
+			}(),
+		},
+		{
+			name: "ctx done",
+			q: func() *ConcurrentLinkedBlockingQueue[int] {
+				return NewConcurrentLinkedBlockingQueue[int](-1)
+			},
+			timeout: -time.Second,
+			value:   1,
+			wantErr: context.DeadlineExceeded,
+			wantRes: []int{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			q := tc.q()
+			err := q.Enqueue(ctx, tc.value)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantRes, q.AsSlice())
+			assert.Equal(t, len(tc.wantRes), q.Len())
+		})
+	}
+}
+
+func TestConcurrentLinkedBlockingQueue_Dequeue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		q       func() *ConcurrentLinkedBlockingQueue[int]
+		timeout time.Duration
+		wantVal int
+		wantErr error
+		wantRes []int
+	}{
+		{
+			name: "empty and timeout",
+			q: func() *ConcurrentLinkedBlockingQueue[int] {
+				return NewConcurrentLinkedBlockingQueue[int](0)
+			},
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+			wantRes: []int{},
+		},
+		{
+			name: "normal",
+			q: func() *ConcurrentLinkedBlockingQueue[int] {
+				q := NewConcurrentLinkedBlockingQueue[int](3)
+				_ = q.Enqueue(context.Background(), 1)
+				_ = q.Enqueue(context.Background(), 2)
+				return q
+			},
+			timeout: time.Second,
+			wantVal: 1,
+			wantRes: []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			q := tc.q()
+			val, err := q.Dequeue(ctx)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantRes, q.AsSlice())
+		})
+	}
+}
+
+func TestConcurrentLinkedBlockingQueue_Block(t *testing.T) {
+	q := NewConcurrentLinkedBlockingQueue[int](0)
+	// 队列为空，出队会阻塞直到有元素入队
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, q.Enqueue(context.Background(), 1))
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	val, err := q.Dequeue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func TestConcurrentLinkedBlockingQueue_Concurrent(t *testing.T) {
+	q := NewConcurrentLinkedBlockingQueue[int](5)
+	const producers, perProducer = 10, 1000
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for i := 0; i < producers; i++ {
+		go func(base int) {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				assert.NoError(t, q.Enqueue(context.Background(), base+j))
+			}
+		}(i * perProducer)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	for i := 0; i < producers*perProducer; i++ {
+		val, err := q.Dequeue(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, seen[val])
+		seen[val] = true
+	}
+	wg.Wait()
+	assert.Equal(t, 0, q.Len())
+}
@@ -1 +1,79 @@
 package queue
+
+import "sync/atomic"
+
+var _ Queue[any] = (*ConcurrentLinkedQueue[any])(nil)
+
+type linkedNode[T any] struct {
+	// val 出队之后节点成为新的哨兵节点，此时将 val 置为 nil，释放对元素的引用，方便 GC
+	val  atomic.Pointer[T]
+	next atomic.Pointer[linkedNode[T]]
+}
+
+// ConcurrentLinkedQueue 无界并发非阻塞队列
+// 基于 Michael-Scott 算法实现，入队和出队都只依赖 CAS 操作，不会使用锁
+// head 永远指向一个哨兵节点，真正的队首是 head.next
+type ConcurrentLinkedQueue[T any] struct {
+	head atomic.Pointer[linkedNode[T]]
+	tail atomic.Pointer[linkedNode[T]]
+}
+
+func NewConcurrentLinkedQueue[T any]() *ConcurrentLinkedQueue[T] {
+	q := &ConcurrentLinkedQueue[T]{}
+	sentinel := &linkedNode[T]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// Enqueue 将元素放入队尾，无界队列永远不会返回错误
+func (c *ConcurrentLinkedQueue[T]) Enqueue(t T) error {
+	n := &linkedNode[T]{}
+	n.val.Store(&t)
+	for {
+		tail := c.tail.Load()
+		next := tail.next.Load()
+		// tail 已经被其它 goroutine 修改，重试
+		if tail != c.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// tail 落后了，帮助其它 goroutine 推进 tail
+			c.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			// 推进 tail 失败也没关系，其它 goroutine 会帮忙推进
+			c.tail.CompareAndSwap(tail, n)
+			return nil
+		}
+	}
+}
+
+// Dequeue 从队首获得一个元素，队列为空时返回 ErrEmptyQueue
+func (c *ConcurrentLinkedQueue[T]) Dequeue() (T, error) {
+	for {
+		head := c.head.Load()
+		tail := c.tail.Load()
+		next := head.next.Load()
+		if head != c.head.Load() {
+			continue
+		}
+		if head == tail {
+			if next == nil {
+				var zero T
+				return zero, ErrEmptyQueue
+			}
+			// tail 落后了，帮助推进
+			c.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// 必须在 CAS 之前读取，CAS 成功之后 next 就成为了新的哨兵节点，val 会被置为 nil。
+		// CAS 失败的 goroutine 读到的 val 可能已经是 nil，但它不会使用 val
+		val := next.val.Load()
+		if c.head.CompareAndSwap(head, next) {
+			next.val.Store(nil)
+			return *val, nil
+		}
+	}
+}
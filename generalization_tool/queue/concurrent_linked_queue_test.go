@@ -0,0 +1,239 @@
+package queue
+
+import (
+	"generalization_tool/list"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentLinkedQueue_Enqueue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		q       func() *ConcurrentLinkedQueue[int]
+		value   int
+		wantRes []int
+	}{
+		{
+			name:    "empty",
+			q:       NewConcurrentLinkedQueue[int],
+			value:   1,
+			wantRes: []int{1},
+		},
+		{
+			name: "not empty",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				_ = q.Enqueue(1)
+				_ = q.Enqueue(2)
+				return q
+			},
+			value:   3,
+			wantRes: []int{1, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			assert.NoError(t, q.Enqueue(tc.value))
+			assert.Equal(t, tc.wantRes, drainLinkedQueue(q))
+		})
+	}
+}
+
+func TestConcurrentLinkedQueue_Dequeue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		q       func() *ConcurrentLinkedQueue[int]
+		wantVal int
+		wantErr error
+		wantRes []int
+	}{
+		{
+			name:    "empty",
+			q:       NewConcurrentLinkedQueue[int],
+			wantErr: ErrEmptyQueue,
+			wantRes: []int{},
+		},
+		{
+			name: "drained",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				_ = q.Enqueue(1)
+				_, _ = q.Dequeue()
+				return q
+			},
+			wantErr: ErrEmptyQueue,
+			wantRes: []int{},
+		},
+		{
+			name: "normal",
+			q: func() *ConcurrentLinkedQueue[int] {
+				q := NewConcurrentLinkedQueue[int]()
+				_ = q.Enqueue(1)
+				_ = q.Enqueue(2)
+				return q
+			},
+			wantVal: 1,
+			wantRes: []int{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := tc.q()
+			val, err := q.Dequeue()
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantVal, val)
+			assert.Equal(t, tc.wantRes, drainLinkedQueue(q))
+		})
+	}
+}
+
+// TestConcurrentLinkedQueue_ReleaseValue 出队之后哨兵节点不再持有元素的引用
+func TestConcurrentLinkedQueue_ReleaseValue(t *testing.T) {
+	q := NewConcurrentLinkedQueue[*int]()
+	v1, v2 := 1, 2
+	assert.NoError(t, q.Enqueue(&v1))
+	assert.NoError(t, q.Enqueue(&v2))
+
+	val, err := q.Dequeue()
+	assert.NoError(t, err)
+	assert.Equal(t, &v1, val)
+	assert.Nil(t, q.head.Load().val.Load())
+
+	val, err = q.Dequeue()
+	assert.NoError(t, err)
+	assert.Equal(t, &v2, val)
+	assert.Nil(t, q.head.Load().val.Load())
+
+	_, err = q.Dequeue()
+	assert.Equal(t, ErrEmptyQueue, err)
+}
+
+// TestConcurrentLinkedQueue_Concurrent 多个生产者和消费者同时操作，
+// 需要配合 -race 运行。每个元素必须恰好被消费一次，且同一个生产者的元素保持 FIFO
+func TestConcurrentLinkedQueue_Concurrent(t *testing.T) {
+	q := NewConcurrentLinkedQueue[int]()
+	const producers, consumers, perProducer = 8, 8, 2000
+	total := producers * perProducer
+
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for i := 0; i < producers; i++ {
+		go func(p int) {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				assert.NoError(t, q.Enqueue(p*perProducer+j))
+			}
+		}(i)
+	}
+
+	var consumed int64
+	results := make([][]int, consumers)
+	var cwg sync.WaitGroup
+	cwg.Add(consumers)
+	for i := 0; i < consumers; i++ {
+		go func(c int) {
+			defer cwg.Done()
+			for atomic.LoadInt64(&consumed) < int64(total) {
+				val, err := q.Dequeue()
+				if err == ErrEmptyQueue {
+					continue
+				}
+				atomic.AddInt64(&consumed, 1)
+				results[c] = append(results[c], val)
+			}
+		}(i)
+	}
+	wg.Wait()
+	cwg.Wait()
+
+	seen := make([]bool, total)
+	for _, res := range results {
+		last := make(map[int]int, producers)
+		for _, val := range res {
+			assert.False(t, seen[val])
+			seen[val] = true
+			p := val / perProducer
+			if prev, ok := last[p]; ok {
+				assert.Less(t, prev, val)
+			}
+			last[p] = val
+		}
+	}
+	for _, ok := range seen {
+		assert.True(t, ok)
+	}
+	_, err := q.Dequeue()
+	assert.Equal(t, ErrEmptyQueue, err)
+}
+
+// BenchmarkConcurrentLinkedQueue 对比无锁队列和基于互斥锁的队列
+func BenchmarkConcurrentLinkedQueue(b *testing.B) {
+	b.Run("lock free", func(b *testing.B) {
+		q := NewConcurrentLinkedQueue[int]()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i&1 == 0 {
+					_ = q.Enqueue(i)
+				} else {
+					_, _ = q.Dequeue()
+				}
+				i++
+			}
+		})
+	})
+	b.Run("mutex", func(b *testing.B) {
+		q := newMutexQueue[int]()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				if i&1 == 0 {
+					_ = q.Enqueue(i)
+				} else {
+					_, _ = q.Dequeue()
+				}
+				i++
+			}
+		})
+	})
+}
+
+// mutexQueue 基于互斥锁和链表的队列，仅用于基准测试对比
+type mutexQueue[T any] struct {
+	mutex sync.Mutex
+	list  *list.LinkedList[T]
+}
+
+func newMutexQueue[T any]() *mutexQueue[T] {
+	return &mutexQueue[T]{list: list.NewLinkedList[T]()}
+}
+
+func (m *mutexQueue[T]) Enqueue(t T) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.list.Append(t)
+}
+
+func (m *mutexQueue[T]) Dequeue() (T, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.list.Len() == 0 {
+		var zero T
+		return zero, ErrEmptyQueue
+	}
+	return m.list.Delete(0)
+}
+
+func drainLinkedQueue[T any](q *ConcurrentLinkedQueue[T]) []T {
+	res := make([]T, 0)
+	for {
+		val, err := q.Dequeue()
+		if err != nil {
+			return res
+		}
+		res = append(res, val)
+	}
+}
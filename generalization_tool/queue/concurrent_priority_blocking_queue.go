@@ -1 +1,93 @@
 package queue
+
+import (
+	"context"
+	"generalization_tool"
+	"generalization_tool/heap"
+	"sync"
+)
+
+var _ BlockingQueue[any] = (*ConcurrentPriorityBlockingQueue[any])(nil)
+
+// ConcurrentPriorityBlockingQueue 并发阻塞优先队列，出队时总是返回当前最小的元素
+// 如果 capacity > 0，那么是有界队列，队列已满时入队会阻塞
+// 如果 capacity <= 0，那么是无界队列，入队永远不会阻塞
+type ConcurrentPriorityBlockingQueue[T any] struct {
+	pq *heap.PriorityQueue[T]
+
+	mutex    *sync.Mutex
+	notEmpty *cond
+	notFull  *cond
+
+	zero T
+}
+
+// NewConcurrentPriorityBlockingQueue 创建一个优先队列，需注意比较器compare不能为nil
+func NewConcurrentPriorityBlockingQueue[T any](capacity int, compare generalization_tool.Comparator[T]) (*ConcurrentPriorityBlockingQueue[T], error) {
+	pq, err := heap.NewPriorityQueue[T](capacity, compare)
+	if err != nil {
+		return nil, err
+	}
+	mutex := &sync.Mutex{}
+	return &ConcurrentPriorityBlockingQueue[T]{
+		pq:       pq,
+		mutex:    mutex,
+		notEmpty: newCond(mutex),
+		notFull:  newCond(mutex),
+	}, nil
+}
+
+// Enqueue 入队，有界队列已满时会阻塞直到有空闲位置，或 ctx 超时/被 cancel
+func (c *ConcurrentPriorityBlockingQueue[T]) Enqueue(ctx context.Context, t T) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.isFull() {
+		if err := c.notFull.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := c.pq.Push(t); err != nil {
+		return err
+	}
+	c.notEmpty.broadcast()
+	return nil
+}
+
+// Dequeue 取出当前最小的元素，队列为空时会阻塞直到有元素，或 ctx 超时/被 cancel
+func (c *ConcurrentPriorityBlockingQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	if ctx.Err() != nil {
+		return c.zero, ctx.Err()
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.pq.Len() == 0 {
+		if err := c.notEmpty.wait(ctx); err != nil {
+			return c.zero, err
+		}
+	}
+	t, err := c.pq.Pop()
+	if err != nil {
+		return c.zero, err
+	}
+	c.notFull.broadcast()
+	return t, nil
+}
+
+func (c *ConcurrentPriorityBlockingQueue[T]) isFull() bool {
+	return !c.pq.IsBoundless() && c.pq.Len() >= c.pq.Cap()
+}
+
+// Len 返回队列中元素的数量
+func (c *ConcurrentPriorityBlockingQueue[T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.pq.Len()
+}
+
+// Cap 返回队列的容量，无界队列返回0
+func (c *ConcurrentPriorityBlockingQueue[T]) Cap() int {
+	return c.pq.Cap()
+}
@@ -0,0 +1,191 @@
+package queue
+
+import (
+	"context"
+	"generalization_tool"
+	"generalization_tool/heap"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewConcurrentPriorityBlockingQueue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capacity int
+		compare  generalization_tool.Comparator[int]
+		wantCap  int
+		wantErr  error
+	}{
+		{
+			name:     "nil comparator",
+			capacity: 10,
+			wantErr:  heap.ErrNilComparator,
+		},
+		{
+			name:     "bounded",
+			capacity: 10,
+			compare:  compare(),
+			wantCap:  10,
+		},
+		{
+			name:     "unbounded",
+			capacity: -1,
+			compare:  compare(),
+			wantCap:  0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := NewConcurrentPriorityBlockingQueue[int](tc.capacity, tc.compare)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantCap, q.Cap())
+			assert.Equal(t, 0, q.Len())
+		})
+	}
+}
+
+func TestConcurrentPriorityBlockingQueue_Enqueue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		capacity int
+		values   []int
+		timeout  time.Duration
+		value    int
+		wantErr  error
+		wantLen  int
+	}{
+		{
+			name:     "bounded not full",
+			capacity: 3,
+			values:   []int{3, 1},
+			timeout:  time.Second,
+			value:    2,
+			wantLen:  3,
+		},
+		{
+			name:     "bounded full and timeout",
+			capacity: 2,
+			values:   []int{3, 1},
+			timeout:  10 * time.Millisecond,
+			value:    2,
+			wantErr:  context.DeadlineExceeded,
+			wantLen:  2,
+		},
+		{
+			name:     "unbounded",
+			capacity: 0,
+			values:   []int{5, 4, 3, 2, 1},
+			timeout:  10 * time.Millisecond,
+			value:    0,
+			wantLen:  6,
+		},
+		{
+			name:     "ctx done",
+			capacity: 0,
+			timeout:  -time.Second,
+			value:    1,
+			wantErr:  context.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := NewConcurrentPriorityBlockingQueue[int](tc.capacity, compare())
+			assert.NoError(t, err)
+			for _, v := range tc.values {
+				assert.NoError(t, q.Enqueue(context.Background(), v))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			err = q.Enqueue(ctx, tc.value)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.wantLen, q.Len())
+		})
+	}
+}
+
+func TestConcurrentPriorityBlockingQueue_Dequeue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		values  []int
+		timeout time.Duration
+		wantRes []int
+		wantErr error
+	}{
+		{
+			name:    "empty and timeout",
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "ctx done",
+			values:  []int{1},
+			timeout: -time.Second,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "min first",
+			values:  []int{5, 1, 4, 2, 3, 1},
+			timeout: time.Second,
+			wantRes: []int{1, 1, 2, 3, 4, 5},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := NewConcurrentPriorityBlockingQueue[int](0, compare())
+			assert.NoError(t, err)
+			for _, v := range tc.values {
+				assert.NoError(t, q.Enqueue(context.Background(), v))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			if tc.wantErr != nil {
+				_, err = q.Dequeue(ctx)
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			res := make([]int, 0, len(tc.values))
+			for q.Len() > 0 {
+				val, err := q.Dequeue(ctx)
+				assert.NoError(t, err)
+				res = append(res, val)
+			}
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestConcurrentPriorityBlockingQueue_Concurrent(t *testing.T) {
+	q, err := NewConcurrentPriorityBlockingQueue[int](10, compare())
+	assert.NoError(t, err)
+	const producers, perProducer = 10, 500
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for i := 0; i < producers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perProducer; j++ {
+				assert.NoError(t, q.Enqueue(context.Background(), rand.Intn(1000)))
+			}
+		}()
+	}
+	for i := 0; i < producers*perProducer; i++ {
+		_, err := q.Dequeue(context.Background())
+		assert.NoError(t, err)
+	}
+	wg.Wait()
+	assert.Equal(t, 0, q.Len())
+}
+
+func compare() generalization_tool.Comparator[int] {
+	return generalization_tool.ComparatorRealNumber[int]
+}
@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cond 可以配合 ctx 使用的条件变量
+// 与 sync.Cond 的区别在于 wait 可以因为 ctx 超时或 cancel 而提前返回
+// 所有方法都必须在持有 L 的情况下调用
+type cond struct {
+	L  sync.Locker
+	ch chan struct{}
+}
+
+func newCond(l sync.Locker) *cond {
+	return &cond{
+		L:  l,
+		ch: make(chan struct{}),
+	}
+}
+
+// wait 释放锁并等待唤醒，返回时会重新持有锁
+// 如果在被唤醒之前 ctx 超时或被 cancel，那么返回 ctx.Err()
+func (c *cond) wait(ctx context.Context) error {
+	ch := c.ch
+	c.L.Unlock()
+	select {
+	case <-ch:
+		c.L.Lock()
+		return nil
+	case <-ctx.Done():
+		c.L.Lock()
+		return ctx.Err()
+	}
+}
+
+// waitTimeout 与 wait 类似，但是最多等待 d 的时间。超时返回 nil，由调用者自行检查条件
+func (c *cond) waitTimeout(ctx context.Context, d time.Duration) error {
+	ch := c.ch
+	c.L.Unlock()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ch:
+		c.L.Lock()
+		return nil
+	case <-timer.C:
+		c.L.Lock()
+		return nil
+	case <-ctx.Done():
+		c.L.Lock()
+		return ctx.Err()
+	}
+}
+
+// broadcast 唤醒所有等待者
+func (c *cond) broadcast() {
+	close(c.ch)
+	c.ch = make(chan struct{})
+}
@@ -1 +1,108 @@
 package queue
+
+import (
+	"context"
+	"generalization_tool/heap"
+	"sync"
+	"time"
+)
+
+var _ BlockingQueue[Delayable] = (*DelayQueue[Delayable])(nil)
+
+// Delayable 延时元素
+type Delayable interface {
+	// Delay 返回元素的剩余延迟时间，小于等于0时表示元素已经到期
+	Delay() time.Duration
+}
+
+// DelayQueue 延时阻塞队列，元素只有在延迟时间到期之后才能出队
+// 如果 capacity > 0，那么是有界队列，队列已满时入队会阻塞
+// 如果 capacity <= 0，那么是无界队列，入队永远不会阻塞
+type DelayQueue[T Delayable] struct {
+	pq *heap.PriorityQueue[T]
+
+	mutex *sync.Mutex
+	// enqueueSignal 有元素入队时唤醒，等待中的出队者会重新检查队首元素
+	enqueueSignal *cond
+	notFull       *cond
+
+	zero T
+}
+
+func NewDelayQueue[T Delayable](capacity int) *DelayQueue[T] {
+	// 比较器不为nil，不会返回错误
+	pq, _ := heap.NewPriorityQueue[T](capacity, func(src T, dst T) int {
+		srcDelay, dstDelay := src.Delay(), dst.Delay()
+		if srcDelay < dstDelay {
+			return -1
+		} else if srcDelay == dstDelay {
+			return 0
+		}
+		return 1
+	})
+	mutex := &sync.Mutex{}
+	return &DelayQueue[T]{
+		pq:            pq,
+		mutex:         mutex,
+		enqueueSignal: newCond(mutex),
+		notFull:       newCond(mutex),
+	}
+}
+
+// Enqueue 入队，有界队列已满时会阻塞直到有空闲位置，或 ctx 超时/被 cancel
+// 入队会唤醒等待中的出队者，如果新元素的延迟时间更短，出队者会改为等待新元素
+func (d *DelayQueue[T]) Enqueue(ctx context.Context, t T) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for !d.pq.IsBoundless() && d.pq.Len() >= d.pq.Cap() {
+		if err := d.notFull.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if err := d.pq.Push(t); err != nil {
+		return err
+	}
+	d.enqueueSignal.broadcast()
+	return nil
+}
+
+// Dequeue 出队，会阻塞直到队首元素的延迟时间到期，或 ctx 超时/被 cancel
+func (d *DelayQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	if ctx.Err() != nil {
+		return d.zero, ctx.Err()
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for {
+		head, err := d.pq.Peek()
+		if err == heap.ErrEmptyHeap {
+			if err := d.enqueueSignal.wait(ctx); err != nil {
+				return d.zero, err
+			}
+			continue
+		}
+		delay := head.Delay()
+		if delay <= 0 {
+			t, err := d.pq.Pop()
+			if err != nil {
+				return d.zero, err
+			}
+			d.notFull.broadcast()
+			return t, nil
+		}
+		// 等待队首元素到期，期间如果有新元素入队，那么重新检查队首
+		if err := d.enqueueSignal.waitTimeout(ctx, delay); err != nil {
+			return d.zero, err
+		}
+	}
+}
+
+// Len 返回队列中元素的数量，包括尚未到期的元素
+func (d *DelayQueue[T]) Len() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.pq.Len()
+}
@@ -0,0 +1,111 @@
+package queue
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDelayQueue_Dequeue(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		elems    []delayElem
+		timeout  time.Duration
+		wantVals []int
+		wantErr  error
+	}{
+		{
+			name:    "empty and timeout",
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "not expired and timeout",
+			elems: []delayElem{
+				{val: 1, deadline: now.Add(time.Minute)},
+			},
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "ctx done",
+			elems: []delayElem{
+				{val: 1, deadline: now.Add(-time.Minute)},
+			},
+			timeout: -time.Second,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "expired in order",
+			elems: []delayElem{
+				{val: 3, deadline: now.Add(30 * time.Millisecond)},
+				{val: 1, deadline: now.Add(-time.Minute)},
+				{val: 2, deadline: now.Add(10 * time.Millisecond)},
+			},
+			timeout:  time.Second,
+			wantVals: []int{1, 2, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewDelayQueue[delayElem](0)
+			for _, e := range tc.elems {
+				assert.NoError(t, q.Enqueue(context.Background(), e))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			if tc.wantErr != nil {
+				_, err := q.Dequeue(ctx)
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			for _, want := range tc.wantVals {
+				e, err := q.Dequeue(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, want, e.val)
+				assert.LessOrEqual(t, e.Delay(), time.Duration(0))
+			}
+			assert.Equal(t, 0, q.Len())
+		})
+	}
+}
+
+func TestDelayQueue_Enqueue(t *testing.T) {
+	q := NewDelayQueue[delayElem](1)
+	assert.NoError(t, q.Enqueue(context.Background(), delayElem{val: 1, deadline: time.Now().Add(time.Minute)}))
+
+	// 队列已满
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := q.Enqueue(ctx, delayElem{val: 2})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, q.Len())
+}
+
+// TestDelayQueue_WakeUpEarly 等待中的出队者在有更短延迟的元素入队时，应该提前醒来
+func TestDelayQueue_WakeUpEarly(t *testing.T) {
+	q := NewDelayQueue[delayElem](0)
+	assert.NoError(t, q.Enqueue(context.Background(), delayElem{val: 1, deadline: time.Now().Add(time.Minute)}))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, q.Enqueue(context.Background(), delayElem{val: 2, deadline: time.Now().Add(10 * time.Millisecond)}))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	e, err := q.Dequeue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, e.val)
+	assert.Equal(t, 1, q.Len())
+}
+
+type delayElem struct {
+	val      int
+	deadline time.Time
+}
+
+func (d delayElem) Delay() time.Duration {
+	return time.Until(d.deadline)
+}
@@ -1,6 +1,16 @@
 package queue
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrEmptyQueue 队列为空
+	ErrEmptyQueue = errors.New("generalization_tool: 队列为空")
+	// ErrInvalidCapacity 有界队列的容量必须大于0
+	ErrInvalidCapacity = errors.New("generalization_tool: 队列容量必须大于0")
+)
 
 // Queue 普通队列
 type Queue[T any] interface {
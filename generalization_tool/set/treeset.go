@@ -37,3 +37,90 @@ func (s *TreeSet[T]) Exist(key T) bool {
 func (s *TreeSet[T]) Keys() []T {
 	return s.treeMap.Keys()
 }
+
+// SubSet 返回 key 在 from 和 to 之间的视图，fromInclusive、toInclusive 分别表示是否包含两个端点
+func (s *TreeSet[T]) SubSet(from T, fromInclusive bool, to T, toInclusive bool) *TreeSetView[T] {
+	return &TreeSetView[T]{view: s.treeMap.SubMap(from, fromInclusive, to, toInclusive)}
+}
+
+// HeadSet 返回 key 小于 to（inclusive 为 true 时小于等于）的视图
+func (s *TreeSet[T]) HeadSet(to T, inclusive bool) *TreeSetView[T] {
+	return &TreeSetView[T]{view: s.treeMap.HeadMap(to, inclusive)}
+}
+
+// TailSet 返回 key 大于 from（inclusive 为 true 时大于等于）的视图
+func (s *TreeSet[T]) TailSet(from T, inclusive bool) *TreeSetView[T] {
+	return &TreeSetView[T]{view: s.treeMap.TailMap(from, inclusive)}
+}
+
+// TreeSetView TreeSet 在某个区间上的只读视图，TreeSet 之后的修改会反映在视图中
+type TreeSetView[T any] struct {
+	view *mapx.TreeMapView[T, any]
+}
+
+func (v *TreeSetView[T]) Exist(key T) bool {
+	return v.view.Contains(key)
+}
+
+// Keys 按照从小到大的顺序返回视图中所有的元素
+func (v *TreeSetView[T]) Keys() []T {
+	return v.view.Keys()
+}
+
+// Len 返回视图中元素的数量，需要遍历整个区间
+func (v *TreeSetView[T]) Len() int {
+	return v.view.Len()
+}
+
+// Range 按照从小到大的顺序遍历视图，fn 返回 false 时停止遍历
+func (v *TreeSetView[T]) Range(fn func(key T) bool) {
+	v.view.Range(func(key T, value any) bool {
+		return fn(key)
+	})
+}
+
+// Rank 返回小于 key 的元素数量，key 不需要存在于TreeSet中，时间复杂度 O(log n)
+func (s *TreeSet[T]) Rank(key T) int {
+	return s.treeMap.Rank(key)
+}
+
+// Select 返回第 i 小的元素（从0开始），i 越界时返回false，时间复杂度 O(log n)
+func (s *TreeSet[T]) Select(i int) (T, bool) {
+	key, _, ok := s.treeMap.Select(i)
+	return key, ok
+}
+
+// TreeSetIterator TreeSet 的迭代器，迭代过程中不能修改 TreeSet
+type TreeSetIterator[T any] struct {
+	it *mapx.TreeMapIterator[T, any]
+}
+
+// Iterator 返回从小到大遍历的迭代器
+func (s *TreeSet[T]) Iterator() *TreeSetIterator[T] {
+	return &TreeSetIterator[T]{it: s.treeMap.Iterator()}
+}
+
+// IteratorFrom 返回从 key 开始、从小到大遍历的迭代器，inclusive 为 true 时包含 key 本身
+func (s *TreeSet[T]) IteratorFrom(key T, inclusive bool) *TreeSetIterator[T] {
+	return &TreeSetIterator[T]{it: s.treeMap.IteratorFrom(key, inclusive)}
+}
+
+// DescendingIterator 返回从大到小遍历的迭代器
+func (s *TreeSet[T]) DescendingIterator() *TreeSetIterator[T] {
+	return &TreeSetIterator[T]{it: s.treeMap.DescendingIterator()}
+}
+
+// DescendingIteratorFrom 返回从 key 开始、从大到小遍历的迭代器，inclusive 为 true 时包含 key 本身
+func (s *TreeSet[T]) DescendingIteratorFrom(key T, inclusive bool) *TreeSetIterator[T] {
+	return &TreeSetIterator[T]{it: s.treeMap.DescendingIteratorFrom(key, inclusive)}
+}
+
+// Next 移动到下一个元素，没有更多元素时返回false
+func (i *TreeSetIterator[T]) Next() bool {
+	return i.it.Next()
+}
+
+// Key 返回当前的元素，必须在 Next 返回 true 之后调用
+func (i *TreeSetIterator[T]) Key() T {
+	return i.it.Key()
+}
@@ -136,6 +136,105 @@ func TestTreeSet_Exist(t *testing.T) {
 	}
 }
 
+func TestTreeSet_View(t *testing.T) {
+	treeSet, err := NewTreeSet[int](compare())
+	require.NoError(t, err)
+	for _, k := range []int{5, 1, 4, 2, 3} {
+		treeSet.Add(k)
+	}
+	testCases := []struct {
+		name     string
+		view     *TreeSetView[int]
+		wantKeys []int
+	}{
+		{
+			name:     "sub set",
+			view:     treeSet.SubSet(2, true, 4, false),
+			wantKeys: []int{2, 3},
+		},
+		{
+			name:     "sub set exclusive",
+			view:     treeSet.SubSet(2, false, 4, true),
+			wantKeys: []int{3, 4},
+		},
+		{
+			name:     "head set",
+			view:     treeSet.HeadSet(3, true),
+			wantKeys: []int{1, 2, 3},
+		},
+		{
+			name:     "tail set",
+			view:     treeSet.TailSet(3, false),
+			wantKeys: []int{4, 5},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantKeys, tc.view.Keys())
+			assert.Equal(t, len(tc.wantKeys), tc.view.Len())
+			assert.True(t, tc.view.Exist(tc.wantKeys[0]))
+			assert.False(t, tc.view.Exist(0))
+			keys := make([]int, 0)
+			tc.view.Range(func(key int) bool {
+				keys = append(keys, key)
+				return false
+			})
+			assert.Equal(t, tc.wantKeys[:1], keys)
+		})
+	}
+
+	// 视图反映 TreeSet 之后的修改
+	view := treeSet.TailSet(4, true)
+	treeSet.Add(6)
+	treeSet.Delete(4)
+	assert.Equal(t, []int{5, 6}, view.Keys())
+}
+
+func TestTreeSet_RankSelect(t *testing.T) {
+	treeSet, err := NewTreeSet[int](compare())
+	require.NoError(t, err)
+	for _, k := range []int{30, 10, 20} {
+		treeSet.Add(k)
+	}
+	assert.Equal(t, 0, treeSet.Rank(10))
+	assert.Equal(t, 2, treeSet.Rank(25))
+	assert.Equal(t, 3, treeSet.Rank(40))
+	key, ok := treeSet.Select(1)
+	assert.True(t, ok)
+	assert.Equal(t, 20, key)
+	_, ok = treeSet.Select(3)
+	assert.False(t, ok)
+	_, ok = treeSet.Select(-1)
+	assert.False(t, ok)
+}
+
+func TestTreeSetIterator(t *testing.T) {
+	treeSet, err := NewTreeSet[int](compare())
+	require.NoError(t, err)
+	for _, k := range []int{3, 1, 4, 2, 5} {
+		treeSet.Add(k)
+	}
+	testCases := []struct {
+		name     string
+		it       *TreeSetIterator[int]
+		wantKeys []int
+	}{
+		{name: "ascending", it: treeSet.Iterator(), wantKeys: []int{1, 2, 3, 4, 5}},
+		{name: "ascending from", it: treeSet.IteratorFrom(3, false), wantKeys: []int{4, 5}},
+		{name: "descending", it: treeSet.DescendingIterator(), wantKeys: []int{5, 4, 3, 2, 1}},
+		{name: "descending from", it: treeSet.DescendingIteratorFrom(3, true), wantKeys: []int{3, 2, 1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := make([]int, 0)
+			for tc.it.Next() {
+				keys = append(keys, tc.it.Key())
+			}
+			assert.Equal(t, tc.wantKeys, keys)
+		})
+	}
+}
+
 // goos: windows
 // goarch: amd64
 // pkg: generalization_tool/set